@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ivan1993spb/snake-bot/internal/arena"
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/config"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+const defaultStrategies = "dijkstras,astar,lookahead,mcts"
+
+func main() {
+	ctx := context.Background()
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var (
+		strategies string
+		profiles   string
+		logLevel   string
+
+		cfg = arena.Config{
+			Bots:  1,
+			Games: 10,
+			Ticks: 1000,
+		}
+
+		width  = 40
+		height = 40
+	)
+
+	f := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	f.StringVar(&strategies, "strategies", defaultStrategies,
+		"comma separated strategies: discoverer:profile")
+	f.StringVar(&profiles, "profiles", "",
+		"path to a YAML file with bot behavior profiles")
+	f.StringVar(&logLevel, "log-level", "info",
+		"log level: panic, fatal, error, warning, info or debug")
+	f.IntVar(&cfg.Bots, "bots", cfg.Bots, "bots per strategy in every game")
+	f.IntVar(&cfg.Games, "games", cfg.Games, "number of games")
+	f.IntVar(&cfg.Ticks, "ticks", cfg.Ticks, "length of every game in ticks")
+	f.IntVar(&width, "width", width, "width of the area")
+	f.IntVar(&height, "height", height, "height of the area")
+	f.IntVar(&cfg.Game.Walls, "walls", 10, "number of walls")
+	f.IntVar(&cfg.Game.Apples, "apples", 20, "number of apples")
+	f.IntVar(&cfg.Game.Watermelons, "watermelons", 2, "number of watermelons")
+	f.IntVar(&cfg.Game.Mice, "mice", 4, "number of mice")
+	f.Int64Var(&cfg.Game.Seed, "seed", 1,
+		"seed to derive the seeds of games and bots from")
+	_ = f.Parse(os.Args[1:])
+
+	ctx = utils.WithLogger(ctx, utils.NewLogger(config.Log{
+		Level: logLevel,
+	}))
+	ctx = utils.WithModule(ctx, "arena")
+	log := utils.GetLogger(ctx)
+
+	if width < 1 || width > math.MaxUint8 || height < 1 ||
+		height > math.MaxUint8 {
+		log.WithFields(logrus.Fields{
+			"width":  width,
+			"height": height,
+		}).Fatal("invalid area size")
+	}
+	cfg.Game.Width = uint8(width)
+	cfg.Game.Height = uint8(height)
+
+	set := bot.NewProfiles()
+	if profiles != "" {
+		file, err := os.Open(profiles)
+		if err != nil {
+			log.WithError(err).Fatal("profiles fail")
+		}
+		err = set.Load(file)
+		file.Close()
+		if err != nil {
+			log.WithError(err).Fatal("profiles fail")
+		}
+	}
+
+	for _, s := range strings.Split(strategies, ",") {
+		strategy, err := arena.ParseStrategy(strings.TrimSpace(s), set)
+		if err != nil {
+			log.WithError(err).Fatal("strategy fail")
+		}
+		cfg.Strategies = append(cfg.Strategies, strategy)
+	}
+
+	log.WithFields(logrus.Fields{
+		"games": cfg.Games,
+		"ticks": cfg.Ticks,
+		"seed":  cfg.Game.Seed,
+	}).Info("tournament started")
+
+	results, err := arena.Run(ctx, cfg)
+	if err != nil {
+		log.WithError(err).Fatal("tournament fail")
+	}
+
+	if err := arena.PrintLeaderboard(os.Stdout, results); err != nil {
+		log.WithError(err).Fatal("leaderboard fail")
+	}
+}
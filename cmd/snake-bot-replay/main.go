@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/config"
+	"github.com/ivan1993spb/snake-bot/internal/replay"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+func main() {
+	ctx := context.Background()
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var (
+		recording  string
+		discoverer string
+		profile    string
+		profiles   string
+		seed       int64
+		realtime   bool
+		logLevel   string
+	)
+
+	f := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	f.StringVar(&recording, "recording", "",
+		"path to a session recorded with -bots-record")
+	f.StringVar(&discoverer, "discoverer", "",
+		"discoverer of the bot, the one of the profile by default")
+	f.StringVar(&profile, "profile", "", "behavior profile of the bot")
+	f.StringVar(&profiles, "profiles", "",
+		"path to a YAML file with bot behavior profiles")
+	f.Int64Var(&seed, "seed", 1, "seed of the bot")
+	f.BoolVar(&realtime, "realtime", false,
+		"keep the recorded pauses between the messages")
+	f.StringVar(&logLevel, "log-level", "info",
+		"log level: panic, fatal, error, warning, info or debug")
+	_ = f.Parse(os.Args[1:])
+
+	ctx = utils.WithLogger(ctx, utils.NewLogger(config.Log{
+		Level: logLevel,
+	}))
+	ctx = utils.WithModule(ctx, "replay")
+	log := utils.GetLogger(ctx)
+
+	if recording == "" {
+		log.Fatal("no recording given")
+	}
+
+	set := bot.NewProfiles()
+	if profiles != "" {
+		file, err := os.Open(profiles)
+		if err != nil {
+			log.WithError(err).Fatal("profiles fail")
+		}
+		err = set.Load(file)
+		file.Close()
+		if err != nil {
+			log.WithError(err).Fatal("profiles fail")
+		}
+	}
+
+	p, ok := set.Get(profile)
+	if !ok {
+		log.WithField("profile", profile).Fatal("unknown profile")
+	}
+	// A replay has to be reproducible whatever the load of the host is.
+	deterministic := *p
+	deterministic.Deterministic = true
+
+	if discoverer == "" {
+		discoverer = deterministic.Discoverer
+	}
+	if discoverer == "" {
+		discoverer = bot.DiscovererDijkstras
+	}
+
+	clock := utils.ImmediatelyClock
+	if realtime {
+		clock = utils.RealClock
+	}
+
+	file, err := os.Open(recording)
+	if err != nil {
+		log.WithError(err).Fatal("recording fail")
+	}
+	defer file.Close()
+
+	result, err := replay.Run(ctx, file, replay.Config{
+		Discoverer: discoverer,
+		Profile:    &deterministic,
+		Seed:       seed,
+		Clock:      clock,
+	})
+	if err != nil {
+		log.WithError(err).Fatal("replay fail")
+	}
+
+	log.WithFields(logrus.Fields{
+		"received": result.Received,
+		"recorded": len(result.Recorded),
+		"sent":     len(result.Sent),
+	}).Info("replay finished")
+
+	// The commands of the replayed bot are printed in the format of
+	// the recording to be compared with the recorded ones.
+	encoder := json.NewEncoder(os.Stdout)
+	for _, record := range result.Sent {
+		if err := encoder.Encode(record); err != nil {
+			log.WithError(err).Fatal("output fail")
+		}
+	}
+}
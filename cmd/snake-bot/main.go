@@ -16,9 +16,14 @@ import (
 
 func main() {
 	ctx := context.Background()
-	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
 	defer cancel()
 
+	// SIGTERM lets the bots finish the lives of their snakes before
+	// the shutdown.
+	drain, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
 	cfg, err := config.StdConfig()
 
 	ctx = utils.WithLogger(ctx, utils.NewLogger(cfg.Log))
@@ -38,7 +43,7 @@ func main() {
 		Config: cfg,
 		Fs:     afero.NewOsFs(),
 		Clock:  utils.RealClock,
-		Rand:   utils.NewRand(utils.RealClock),
+		Drain:  drain.Done(),
 	}
 
 	application.Run(ctx)
@@ -7,10 +7,12 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 
+	"github.com/ivan1993spb/snake-bot/internal/bot"
 	"github.com/ivan1993spb/snake-bot/internal/config"
 	"github.com/ivan1993spb/snake-bot/internal/connect"
 	"github.com/ivan1993spb/snake-bot/internal/core"
 	"github.com/ivan1993spb/snake-bot/internal/http"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 	"github.com/ivan1993spb/snake-bot/internal/secure"
 	"github.com/ivan1993spb/snake-bot/internal/utils"
 )
@@ -19,12 +21,17 @@ type App struct {
 	Config config.Config
 	Fs     afero.Fs
 	Clock  utils.Clock
-	Rand   core.Rand
+	// Drain is optional. The application is drained and shut down once
+	// the channel is closed.
+	Drain <-chan struct{}
 }
 
 const shutdownTimeout = time.Second * 5
 
 func (a *App) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	log := utils.GetLogger(ctx)
 
 	log.WithFields(logrus.Fields{
@@ -41,18 +48,51 @@ func (a *App) Run(ctx context.Context) {
 		log.WithError(err).Fatal("security fail")
 	}
 
+	if !bot.IsDiscoverer(a.Config.Bots.Discoverer) {
+		log.WithField("discoverer", a.Config.Bots.Discoverer).Fatal(
+			"unknown discoverer")
+	}
+
+	// Profiles define the behavior of bots.
+	profiles, err := a.loadProfiles(ctx)
+	if err != nil {
+		log.WithError(err).Fatal("profiles fail")
+	}
+
 	// Module "connect" is responsible for connecting to the target server.
-	connector := connect.NewConnector(a.Config.Target, headerAppInfo)
+	var connector core.Connector = connect.NewConnector(a.Config.Target,
+		headerAppInfo)
+	if path := a.Config.Bots.Record; path != "" {
+		log.WithField("path", path).Info("recording sessions")
+		connector = connect.NewRecordingConnector(connector, a.Fs, path,
+			a.Clock)
+	}
+
+	// events delivers the events of the bots to the API.
+	events := core.NewBus()
 
 	// factory creates bot operators which are responsible for
 	// managing bots and their sessions.
-	factory := &core.DijkstrasBotOperatorFactory{
-		Logger:    utils.GetLogger(utils.WithModule(ctx, "notification")),
-		Rand:      a.Rand,
-		Connector: connector,
-		Clock:     a.Clock,
+	factory := &core.DefaultBotOperatorFactory{
+		Logger:     utils.GetLogger(utils.WithModule(ctx, "notification")),
+		Connector:  connector,
+		Clock:      a.Clock,
+		Profiles:   profiles,
+		Discoverer: a.Config.Bots.Discoverer,
+		Seed:       a.Config.Bots.Seed,
+		Events:     events,
+		Backoff: core.Backoff{
+			Min: a.Config.Bots.BackoffMin,
+			Max: a.Config.Bots.BackoffMax,
+
+			StartMin: a.Config.Bots.DelayMin,
+			StartMax: a.Config.Bots.DelayMax,
+		},
 	}
 
+	// games lists the games of the target server.
+	games := connect.NewGamesClient(a.Config.Target, headerAppInfo)
+
 	// Storage is responsible for storing the state.
 	storage := core.NewStorage(a.Fs, a.Config.Storage)
 	log.WithField("storage", storage.Type()).Info("storage initialized")
@@ -63,10 +103,40 @@ func (a *App) Run(ctx context.Context) {
 		BotOperatorFactory: factory,
 		Clock:              a.Clock,
 		Storage:            storage,
+		Profiles:           profiles,
+		Games:              games,
+		FillInterval:       a.Config.Fill.Interval,
+		Events:             events,
+		DrainTimeout:       a.Config.Bots.Drain,
 	})
 
+	// The fill policy makes the core keep bots in the games of the target
+	// server. A policy set through the API replaces the configured one.
+	if err := appCore.SetDefaultFillPolicy(ctx, a.fillPolicy()); err != nil {
+		log.WithError(err).Fatal("fill policy fail")
+	}
+
 	done := appCore.Run(utils.WithModule(ctx, "core"))
 
+	// Once the core is drained by a signal or through the API,
+	// the application shuts down.
+	go func() {
+		select {
+		case <-a.Drain:
+			appCore.Drain(utils.WithModule(ctx, "core"))
+		case <-appCore.Drained():
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-appCore.Drained():
+			log.Info("drained, shutting down")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	// Start the REST API server.
 	server := http.NewServer(http.ServerParams{
 		Config:  a.Config.Server,
@@ -88,3 +158,42 @@ func (a *App) Run(ctx context.Context) {
 
 	log.Info("buh bye!")
 }
+
+// fillPolicy returns the configured fill policy or nil if it is disabled.
+func (a *App) fillPolicy() *models.FillPolicy {
+	policy := &models.FillPolicy{
+		Occupancy: a.Config.Fill.Occupancy,
+		Players:   a.Config.Fill.Players,
+		Yield:     a.Config.Fill.Yield,
+		MinBots:   a.Config.Fill.MinBots,
+		MaxBots:   a.Config.Fill.MaxBots,
+	}
+	if !policy.Enabled() {
+		return nil
+	}
+	return policy
+}
+
+func (a *App) loadProfiles(ctx context.Context) (*bot.Profiles, error) {
+	log := utils.GetLogger(ctx)
+
+	profiles := bot.NewProfiles()
+
+	if path := a.Config.Bots.Profiles; path != "" {
+		log.WithField("path", path).Info("loading profiles")
+
+		f, err := a.Fs.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		if err := profiles.Load(f); err != nil {
+			return nil, err
+		}
+	}
+
+	log.WithField("profiles", profiles.Names()).Info("profiles initialized")
+
+	return profiles, nil
+}
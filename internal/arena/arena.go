@@ -0,0 +1,203 @@
+package arena
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/parser"
+	"github.com/ivan1993spb/snake-bot/internal/simulator"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+// Strategy is a combination of a path discovery algorithm and
+// a behavior profile.
+type Strategy struct {
+	Discoverer string
+	Profile    *bot.Profile
+}
+
+// Name returns the strategy in the form accepted by ParseStrategy.
+func (s Strategy) Name() string {
+	return s.Discoverer + ":" + s.Profile.Name
+}
+
+const strategySeparator = ":"
+
+var (
+	ErrUnknownProfile = errors.New("unknown profile")
+	ErrNoStrategies   = errors.New("no strategies")
+)
+
+// ParseStrategy parses a strategy in the form discoverer:profile. Both
+// parts are optional. If the discoverer is omitted, the one set by the
+// profile or Dijkstra's algorithm is used. The default profile stands
+// for an omitted profile. The profile of the strategy is a deterministic
+// copy of the named one.
+func ParseStrategy(s string, profiles *bot.Profiles) (Strategy, error) {
+	discoverer, name := s, ""
+	if i := strings.Index(s, strategySeparator); i >= 0 {
+		discoverer, name = s[:i], s[i+len(strategySeparator):]
+	}
+
+	profile, ok := profiles.Get(name)
+	if !ok {
+		return Strategy{}, errors.WithMessagef(ErrUnknownProfile,
+			"strategy %q", s)
+	}
+
+	if discoverer == "" {
+		discoverer = profile.Discoverer
+	}
+	if discoverer == "" {
+		discoverer = bot.DiscovererDijkstras
+	}
+	if !bot.IsDiscoverer(discoverer) {
+		return Strategy{}, errors.WithMessagef(bot.ErrUnknownDiscoverer,
+			"strategy %q", s)
+	}
+
+	// The searches of the arena bots must not depend on the time they
+	// take, otherwise the seed wouldn't define the tournament.
+	deterministic := *profile
+	deterministic.Deterministic = true
+
+	return Strategy{
+		Discoverer: discoverer,
+		Profile:    &deterministic,
+	}, nil
+}
+
+// Config describes a tournament. Every game of the tournament is
+// played by all the strategies on a new simulated area. The seed of
+// the game config is the seed the seeds of the games and of the bots
+// are derived from.
+type Config struct {
+	Strategies []Strategy
+	// Bots is the number of the bots per strategy in every game.
+	Bots  int
+	Games int
+	// Ticks is the length of every game.
+	Ticks int
+	Game  simulator.Config
+}
+
+// Result is the performance of a strategy over a tournament.
+type Result struct {
+	Strategy string
+	// Bots is the number of the bots which played for the strategy
+	// over all the games.
+	Bots  int
+	Stats simulator.Stats
+}
+
+type contender struct {
+	strategy int
+	player   *simulator.Player
+	parser   *parser.Parser
+	bot      *bot.Bot
+}
+
+// Run plays the tournament. Bots make decisions on every tick of
+// the simulation. The results are in the order of the strategies.
+func Run(ctx context.Context, config Config) ([]Result, error) {
+	if len(config.Strategies) == 0 {
+		return nil, ErrNoStrategies
+	}
+
+	log := utils.GetLogger(ctx)
+
+	results := make([]Result, len(config.Strategies))
+	for i, strategy := range config.Strategies {
+		results[i].Strategy = strategy.Name()
+	}
+
+	for game := 0; game < config.Games; game++ {
+		contenders, err := play(ctx, config, game)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range contenders {
+			r := &results[c.strategy]
+			stats := c.player.Stats()
+			r.Bots++
+			r.Stats.Lives += stats.Lives
+			r.Stats.Kills += stats.Kills
+			r.Stats.Deaths += stats.Deaths
+			r.Stats.Ticks += stats.Ticks
+			r.Stats.TotalLength += stats.TotalLength
+			if stats.MaxLength > r.Stats.MaxLength {
+				r.Stats.MaxLength = stats.MaxLength
+			}
+		}
+
+		log.WithFields(logrus.Fields{
+			"game":  game + 1,
+			"games": config.Games,
+		}).Debug("game over")
+	}
+
+	return results, nil
+}
+
+func play(ctx context.Context, config Config, game int) ([]*contender,
+	error) {
+	gameConfig := config.Game
+	gameConfig.Seed = utils.DeriveSeed(config.Game.Seed, int64(game))
+	sim := simulator.New(gameConfig)
+
+	count := len(config.Strategies) * config.Bots
+	contenders := make([]*contender, 0, count)
+
+	for i := 0; i < count; i++ {
+		// The strategies take turns to join first as the first players
+		// get more room.
+		strategy := (i + game) % len(config.Strategies)
+		world := bot.NewGame()
+		b, err := bot.NewBotWithDiscoverer(world,
+			config.Strategies[strategy].Discoverer,
+			config.Strategies[strategy].Profile,
+			utils.DeriveSeed(config.Game.Seed, int64(game), int64(i)))
+		if err != nil {
+			return nil, err
+		}
+
+		contenders = append(contenders, &contender{
+			strategy: strategy,
+			player:   sim.Join(),
+			parser: &parser.Parser{
+				Countdown: b,
+				Me:        b,
+				Size:      world,
+				Game:      world,
+				Printer:   utils.PrinterDevNull{},
+			},
+			bot: b,
+		})
+	}
+
+	for tick := 0; tick < config.Ticks; tick++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, c := range contenders {
+			for _, message := range c.player.Receive() {
+				if err := c.parser.Parse(message); err != nil {
+					return nil, err
+				}
+			}
+			if direction, ok := c.bot.Tick(ctx); ok {
+				c.player.Send(direction)
+			}
+		}
+
+		sim.Tick()
+	}
+
+	return contenders, nil
+}
@@ -0,0 +1,129 @@
+package arena
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/simulator"
+)
+
+func Test_ParseStrategy(t *testing.T) {
+	profiles := bot.NewProfiles()
+
+	tests := []struct {
+		strategy string
+		expected string
+		err      error
+	}{
+		{"", "dijkstras:default", nil},
+		{"astar", "astar:default", nil},
+		{"astar:hunter", "astar:hunter", nil},
+		{":hunter", "dijkstras:hunter", nil},
+		{":swarm", "mcts:swarm", nil},
+		{"lookahead:swarm", "lookahead:swarm", nil},
+		{"astar:unknown", "", ErrUnknownProfile},
+		{"unknown:hunter", "", bot.ErrUnknownDiscoverer},
+	}
+
+	for _, test := range tests {
+		t.Run(test.strategy, func(t *testing.T) {
+			strategy, err := ParseStrategy(test.strategy, profiles)
+			if test.err != nil {
+				require.True(t, errors.Is(err, test.err))
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, test.expected, strategy.Name())
+			require.True(t, strategy.Profile.Deterministic)
+		})
+	}
+}
+
+func Test_Run(t *testing.T) {
+	profiles := bot.NewProfiles()
+	dijkstras, err := ParseStrategy("dijkstras", profiles)
+	require.Nil(t, err)
+	astar, err := ParseStrategy("astar:hunter", profiles)
+	require.Nil(t, err)
+
+	config := Config{
+		Strategies: []Strategy{dijkstras, astar},
+		Bots:       2,
+		Games:      2,
+		Ticks:      100,
+		Game: simulator.Config{
+			Width:  30,
+			Height: 30,
+			Walls:  4,
+			Apples: 10,
+			Mice:   2,
+			Seed:   1,
+		},
+	}
+
+	results, err := Run(context.Background(), config)
+	require.Nil(t, err)
+	require.Len(t, results, 2)
+	for i, result := range results {
+		require.Equal(t, config.Strategies[i].Name(), result.Strategy)
+		require.Equal(t, 4, result.Bots)
+		require.Positive(t, result.Stats.Ticks)
+		require.GreaterOrEqual(t, result.Stats.Lives, result.Bots)
+	}
+
+	var buf bytes.Buffer
+	require.Nil(t, PrintLeaderboard(&buf, results))
+	require.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), 3)
+}
+
+func Test_Run_Reproducible(t *testing.T) {
+	profiles := bot.NewProfiles()
+	lookahead, err := ParseStrategy("lookahead", profiles)
+	require.Nil(t, err)
+	swarm, err := ParseStrategy(":swarm", profiles)
+	require.Nil(t, err)
+
+	config := Config{
+		Strategies: []Strategy{lookahead, swarm},
+		Bots:       1,
+		Games:      1,
+		Ticks:      30,
+		Game: simulator.Config{
+			Width:  20,
+			Height: 20,
+			Apples: 10,
+			Seed:   1,
+		},
+	}
+
+	first, err := Run(context.Background(), config)
+	require.Nil(t, err)
+	second, err := Run(context.Background(), config)
+	require.Nil(t, err)
+	require.Equal(t, first, second)
+}
+
+func Test_Run_NoStrategies(t *testing.T) {
+	_, err := Run(context.Background(), Config{})
+	require.Equal(t, ErrNoStrategies, err)
+}
+
+func Test_Leaderboard(t *testing.T) {
+	results := []Result{
+		{Strategy: "a", Stats: simulator.Stats{Ticks: 10, TotalLength: 30}},
+		{Strategy: "b", Stats: simulator.Stats{Ticks: 10, TotalLength: 50}},
+		{Strategy: "c", Stats: simulator.Stats{Ticks: 10, TotalLength: 40}},
+	}
+
+	board := Leaderboard(results)
+	require.Equal(t, "b", board[0].Strategy)
+	require.Equal(t, "c", board[1].Strategy)
+	require.Equal(t, "a", board[2].Strategy)
+	require.Equal(t, "a", results[0].Strategy)
+}
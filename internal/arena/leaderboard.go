@@ -0,0 +1,47 @@
+package arena
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// Leaderboard sorts the results by the average length of the snakes.
+func Leaderboard(results []Result) []Result {
+	board := append([]Result(nil), results...)
+	sort.SliceStable(board, func(i, j int) bool {
+		return board[i].Stats.AverageLength() > board[j].Stats.AverageLength()
+	})
+	return board
+}
+
+// PrintLeaderboard writes the results as a table. Kills and deaths are
+// given per bot per game.
+func PrintLeaderboard(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(tw, "#\tSTRATEGY\tAVG LENGTH\tMAX LENGTH\t"+
+		"AVG SURVIVAL\tKILLS\tDEATHS\t")
+
+	for i, r := range Leaderboard(results) {
+		fmt.Fprintf(tw, "%d\t%s\t%.2f\t%d\t%.1f\t%.2f\t%.2f\t\n",
+			i+1,
+			r.Strategy,
+			r.Stats.AverageLength(),
+			r.Stats.MaxLength,
+			r.Stats.AverageSurvival(),
+			perBot(r.Stats.Kills, r.Bots),
+			perBot(r.Stats.Deaths, r.Bots),
+		)
+	}
+
+	return tw.Flush()
+}
+
+func perBot(value, bots int) float64 {
+	if bots == 0 {
+		return 0
+	}
+	return float64(value) / float64(bots)
+}
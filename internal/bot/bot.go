@@ -2,7 +2,9 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -13,10 +15,6 @@ import (
 	"github.com/ivan1993spb/snake-bot/internal/utils"
 )
 
-const botTickTime = time.Millisecond * 200
-
-const lookupDistance uint8 = 50
-
 const directionExpireTime = time.Millisecond * 10
 
 const (
@@ -36,24 +34,138 @@ type Bot struct {
 	myId  uint32
 	world World
 
+	snake      *engine.Snake
+	opponents  *engine.Opponents
 	discoverer engine.Discoverer
 
+	profile  *Profile
+	behavior map[scoreType]int
+
 	lastPosition  types.Dot
 	lastDirection types.Direction
+
+	viewers viewers
+
+	// The last tick is kept in place and drawn only when requested.
+	renderingMux sync.Mutex
+	rendering    engine.Rendering
+	rendered     bool
 }
 
-func NewBot(world World, discoverer engine.Discoverer) *Bot {
+// NewBot creates a bot. The snake and the opponents are updated by the
+// bot on every tick and can be shared with the components of the
+// discoverer.
+func NewBot(world World, snake *engine.Snake, opponents *engine.Opponents,
+	discoverer engine.Discoverer, profile *Profile) *Bot {
 	return &Bot{
 		world:      world,
+		snake:      snake,
+		opponents:  opponents,
 		discoverer: discoverer,
+
+		profile:  profile,
+		behavior: profile.behavior(),
 	}
 }
 
-func NewDijkstrasBot(world World) *Bot {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	discoverer := engine.NewDijkstrasDiscoverer(r)
-	cacher := engine.NewCacherDiscoverer(discoverer)
-	return NewBot(world, cacher)
+// NewDijkstrasBot creates a bot driven by Dijkstra's algorithm. The seed
+// makes the decisions of the bot reproducible.
+func NewDijkstrasBot(world World, profile *Profile, seed int64) *Bot {
+	r := rand.New(rand.NewSource(seed))
+	snake := engine.NewSnake()
+	survival := engine.NewSurvival(snake)
+	discoverer := engine.NewDijkstrasDiscoverer(r, survival)
+	fallback := engine.NewTailDiscoverer(snake)
+	cacher := engine.NewCacherDiscoverer(discoverer, fallback)
+	return NewBot(world, snake, engine.NewOpponents(), cacher, profile)
+}
+
+func NewAStarBot(world World, profile *Profile, seed int64) *Bot {
+	snake := engine.NewSnake()
+	survival := engine.NewSurvival(snake)
+	discoverer := engine.NewAStarDiscoverer(survival)
+	fallback := engine.NewTailDiscoverer(snake)
+	cacher := engine.NewCacherDiscoverer(discoverer, fallback)
+	return NewBot(world, snake, engine.NewOpponents(), cacher, profile)
+}
+
+// lookaheadBudgetDivisor sets the part of the tick which the lookahead
+// and the Monte Carlo tree searches may take.
+const lookaheadBudgetDivisor = 4
+
+// searchClock returns the clock the lookahead and the Monte Carlo tree
+// searches check their budgets by. A deterministic profile freezes it.
+func searchClock(profile *Profile) utils.Clock {
+	if profile.Deterministic {
+		return utils.NeverClock
+	}
+	return utils.RealClock
+}
+
+// NewLookaheadBot creates a bot which searches the game tree when other
+// snakes are close and uses Dijkstra's algorithm otherwise.
+func NewLookaheadBot(world World, profile *Profile, seed int64) *Bot {
+	r := rand.New(rand.NewSource(seed))
+	snake := engine.NewSnake()
+	opponents := engine.NewOpponents()
+	survival := engine.NewSurvival(snake)
+	discoverer := engine.NewDijkstrasDiscoverer(r, survival)
+	fallback := engine.NewTailDiscoverer(snake)
+	cacher := engine.NewCacherDiscoverer(discoverer, fallback)
+	budget := profile.TickTime / lookaheadBudgetDivisor
+	lookahead := engine.NewLookaheadDiscoverer(snake, opponents, cacher,
+		budget, searchClock(profile))
+	return NewBot(world, snake, opponents, lookahead, profile)
+}
+
+// NewMCTSBot creates a bot which runs Monte Carlo tree search when
+// other snakes are around and uses Dijkstra's algorithm otherwise.
+func NewMCTSBot(world World, profile *Profile, seed int64) *Bot {
+	r := rand.New(rand.NewSource(seed))
+	snake := engine.NewSnake()
+	opponents := engine.NewOpponents()
+	survival := engine.NewSurvival(snake)
+	discoverer := engine.NewDijkstrasDiscoverer(r, survival)
+	fallback := engine.NewTailDiscoverer(snake)
+	cacher := engine.NewCacherDiscoverer(discoverer, fallback)
+	budget := profile.TickTime / lookaheadBudgetDivisor
+	mcts := engine.NewMCTSDiscoverer(snake, opponents, cacher, r,
+		profile.Rollouts, budget, searchClock(profile))
+	return NewBot(world, snake, opponents, mcts, profile)
+}
+
+// Names of the path discovery algorithms a bot can be driven by.
+const (
+	DiscovererDijkstras = "dijkstras"
+	DiscovererAStar     = "astar"
+	DiscovererLookahead = "lookahead"
+	DiscovererMCTS      = "mcts"
+)
+
+var botConstructors = map[string]func(world World, profile *Profile,
+	seed int64) *Bot{
+	DiscovererDijkstras: NewDijkstrasBot,
+	DiscovererAStar:     NewAStarBot,
+	DiscovererLookahead: NewLookaheadBot,
+	DiscovererMCTS:      NewMCTSBot,
+}
+
+var ErrUnknownDiscoverer = errors.New("unknown discoverer")
+
+// NewBotWithDiscoverer creates a bot driven by the discoverer with
+// the given name.
+func NewBotWithDiscoverer(world World, discoverer string,
+	profile *Profile, seed int64) (*Bot, error) {
+	constructor, ok := botConstructors[discoverer]
+	if !ok {
+		return nil, ErrUnknownDiscoverer
+	}
+	return constructor(world, profile, seed), nil
+}
+
+func IsDiscoverer(discoverer string) bool {
+	_, ok := botConstructors[discoverer]
+	return ok
 }
 
 func (b *Bot) Run(ctx context.Context) <-chan types.Direction {
@@ -61,7 +173,7 @@ func (b *Bot) Run(ctx context.Context) <-chan types.Direction {
 
 	go func() {
 		defer close(chout)
-		ticker := time.NewTicker(botTickTime)
+		ticker := time.NewTicker(b.profile.TickTime)
 		defer ticker.Stop()
 
 		for {
@@ -85,6 +197,12 @@ func (b *Bot) Run(ctx context.Context) <-chan types.Direction {
 	return chout
 }
 
+// Tick makes the bot decide on the direction of its snake. Run calls it
+// every tick, whereas simulations may call it directly.
+func (b *Bot) Tick(ctx context.Context) (types.Direction, bool) {
+	return b.operate(ctx)
+}
+
 func (b *Bot) getState() uint32 {
 	return atomic.LoadUint32(&b.state)
 }
@@ -109,16 +227,26 @@ func (b *Bot) operate(ctx context.Context) (types.Direction, bool) {
 		return types.DirectionZero, false
 	}
 	head := objectDots[0]
-	sight := engine.NewSight(area, head, lookupDistance)
+	b.snake.Update(objectDots)
+	sight := engine.NewSight(area, head, b.profile.LookupDistance)
 
 	objects := b.world.LookAround(sight)
-	scores := b.score(objects)
+	opponents := b.lookOpponents(objects)
+	b.opponents.Update(opponents)
+	scores := b.score(area, objects, opponents)
 
 	path := b.discoverer.Discover(head, area, sight, scores)
+	b.setRendering(sight, objects, scores, path)
 	if len(path) == 0 {
+		if b.watched() {
+			b.publishView(b.makeView(head, sight, scores, path, ""))
+		}
 		return types.DirectionZero, false
 	}
 	direction := area.FindDirection(head, path[0])
+	if b.watched() {
+		b.publishView(b.makeView(head, sight, scores, path, direction))
+	}
 
 	if area.FindDirection(objectDots[1], objectDots[0]) == direction {
 		// the same direction
@@ -137,6 +265,29 @@ func (b *Bot) operate(ctx context.Context) (types.Direction, bool) {
 	return types.DirectionZero, false
 }
 
+// ObserveDiscovery makes the bot report how long its discoverer takes to
+// find a path. It has to be called before the bot runs.
+func (b *Bot) ObserveDiscovery(observe func(time.Duration)) {
+	b.discoverer = engine.NewTimedDiscoverer(b.discoverer, observe)
+}
+
+// Snake returns the id of the bot's snake, the length of the snake and
+// whether the snake is alive.
+func (b *Bot) Snake() (uint32, int, bool) {
+	id := b.getMe()
+	alive := b.getState() == stateExplore
+	if id == 0 {
+		return 0, 0, false
+	}
+
+	me, ok := b.world.GetObject(id)
+	if !ok {
+		return id, 0, alive
+	}
+
+	return id, len(me.GetDots()), alive
+}
+
 func (b *Bot) Countdown(sec int) {
 	// TODO: Shut down the snake if the stateWait
 	atomic.StoreUint32(&b.state, stateWait)
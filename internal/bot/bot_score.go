@@ -14,20 +14,33 @@ const (
 	scoreTypeFoodWatermelon
 	scoreTypeFoodMouse
 	scoreTypeHunt
+	scoreTypeDanger
 )
 
-var defaultBehavior = map[scoreType]int{
-	scoreTypeCollapse:       -1000,
-	scoreTypeFoodApple:      1,
-	scoreTypeFoodCorpse:     2,
-	scoreTypeFoodWatermelon: 5,
-	scoreTypeFoodMouse:      15,
-	scoreTypeHunt:           30,
+// lookOpponents returns the other snakes in sight.
+func (b *Bot) lookOpponents(objects *engine.HashmapSight) []*types.Object {
+	me := b.getMe()
+	seen := make(map[uint32]struct{})
+	var opponents []*types.Object
+
+	objects.ForEach(func(dot types.Dot, v interface{}) {
+		object, ok := v.(*types.Object)
+		if !ok || object.Type != types.ObjectTypeSnake || object.Id == me {
+			return
+		}
+		if _, ok := seen[object.Id]; !ok {
+			seen[object.Id] = struct{}{}
+			opponents = append(opponents, object)
+		}
+	})
+
+	return opponents
 }
 
-func (b *Bot) score(objects *engine.HashmapSight) *engine.HashmapSight {
-	// TODO: Estimate the real prey length.
-	const preyLen = 3
+func (b *Bot) score(area engine.Area, objects *engine.HashmapSight,
+	opponents []*types.Object) *engine.HashmapSight {
+	me := b.getMe()
+	myLen := b.snake.Len()
 
 	scores := objects.Reflect()
 
@@ -36,32 +49,97 @@ func (b *Bot) score(objects *engine.HashmapSight) *engine.HashmapSight {
 		if !ok {
 			return
 		}
-		if object.Id == b.myId {
-			scores.Assign(dot, defaultBehavior[scoreTypeCollapse])
+		if object.Id == me {
+			scores.Assign(dot, b.behavior[scoreTypeCollapse])
 		} else if object.Type == types.ObjectTypeSnake {
-			if len(object.Dots) == preyLen {
-				scores.Assign(dot, defaultBehavior[scoreTypeHunt])
-			} else {
-				scores.Assign(dot, defaultBehavior[scoreTypeCollapse])
-			}
+			// Snakes are obstacles. The regions around their heads
+			// are scored below once all the objects are placed.
+			scores.Assign(dot, b.behavior[scoreTypeCollapse])
 		} else if object.Type == types.ObjectTypeWall {
-			scores.Assign(dot, defaultBehavior[scoreTypeCollapse])
+			scores.Assign(dot, b.behavior[scoreTypeCollapse])
 		} else {
 			switch object.Type {
 			case types.ObjectTypeApple:
-				scores.Assign(dot, defaultBehavior[scoreTypeFoodApple])
+				scores.Assign(dot, b.behavior[scoreTypeFoodApple])
 			case types.ObjectTypeCorpse:
-				scores.Assign(dot, defaultBehavior[scoreTypeFoodCorpse])
+				scores.Assign(dot, b.behavior[scoreTypeFoodCorpse])
 			case types.ObjectTypeWatermelon:
-				scores.Assign(dot, defaultBehavior[scoreTypeFoodWatermelon])
+				scores.Assign(dot, b.behavior[scoreTypeFoodWatermelon])
 			case types.ObjectTypeMouse:
-				scores.Assign(dot, defaultBehavior[scoreTypeFoodMouse])
+				scores.Assign(dot, b.behavior[scoreTypeFoodMouse])
 			default:
 				// Avoid unknown objects.
-				scores.Assign(dot, defaultBehavior[scoreTypeCollapse])
+				scores.Assign(dot, b.behavior[scoreTypeCollapse])
 			}
 		}
 	})
 
+	threats := make([]*types.Object, 0, len(opponents))
+	for _, object := range opponents {
+		if len(object.Dots) == 0 {
+			continue
+		}
+		if len(object.Dots) < myLen {
+			b.scorePrey(area, scores, object)
+		} else {
+			threats = append(threats, object)
+		}
+	}
+	// Threats are scored last in order to override the prey's dots.
+	for _, object := range threats {
+		b.scoreThreat(area, scores, object)
+	}
+
 	return scores
 }
+
+// preyScore returns the score of a snake which is smaller than the bot.
+// The prey is valued as the corpse it turns into. Bots whose profiles
+// have no positive hunt score keep away from other snakes.
+func (b *Bot) preyScore(prey *types.Object) int {
+	hunt := b.behavior[scoreTypeHunt]
+	if hunt <= 0 {
+		return hunt
+	}
+	return hunt + len(prey.Dots)*b.behavior[scoreTypeFoodCorpse]
+}
+
+// scorePrey targets the head of a smaller snake and the dots where the
+// head can be on the next tick. A prey which is not worth hunting is left
+// an obstacle.
+func (b *Bot) scorePrey(area engine.Area, scores *engine.HashmapSight,
+	prey *types.Object) {
+	score := b.preyScore(prey)
+	if score <= 0 {
+		return
+	}
+
+	head := prey.Dots[0]
+	scores.Assign(head, score)
+
+	for _, dot := range engine.NextHeads(area, prey) {
+		if current, ok := scores.Access(dot); ok {
+			if current.(int) < 0 || current.(int) >= score {
+				continue
+			}
+		}
+		scores.Assign(dot, score)
+	}
+}
+
+// scoreThreat marks the dots where the head of a snake which is not
+// smaller than the bot can be on the next tick. Bots lose head-to-head
+// collisions with such snakes.
+func (b *Bot) scoreThreat(area engine.Area, scores *engine.HashmapSight,
+	threat *types.Object) {
+	danger := b.behavior[scoreTypeDanger]
+	if danger >= 0 {
+		return
+	}
+	for _, dot := range engine.NextHeads(area, threat) {
+		if current, ok := scores.Access(dot); ok && current.(int) <= danger {
+			continue
+		}
+		scores.Assign(dot, danger)
+	}
+}
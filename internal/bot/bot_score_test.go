@@ -0,0 +1,173 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot/engine"
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+func scoreFixture(profile *Profile, objects ...*types.Object) (
+	*engine.HashmapSight, engine.Area) {
+	area := engine.NewArea(30, 30)
+	me := &types.Object{
+		Type: types.ObjectTypeSnake,
+		Id:   1,
+		Dots: []types.Dot{
+			{X: 10, Y: 10},
+			{X: 10, Y: 11},
+			{X: 10, Y: 12},
+			{X: 10, Y: 13},
+			{X: 10, Y: 14},
+		},
+	}
+
+	m := engine.NewMap(area)
+	m.SaveObject(me)
+	for _, object := range objects {
+		m.SaveObject(object)
+	}
+
+	snake := engine.NewSnake()
+	snake.Update(me.Dots)
+	b := NewBot(nil, snake, engine.NewOpponents(), nil, profile)
+	b.Me(me.Id)
+
+	sight := engine.NewSight(area, me.Dots[0], profile.LookupDistance)
+	around := m.LookAround(sight)
+	return b.score(area, around, b.lookOpponents(around)), area
+}
+
+func Test_Bot_score_Prey(t *testing.T) {
+	profile := defaultProfile
+	prey := &types.Object{
+		Type: types.ObjectTypeSnake,
+		Id:   2,
+		Dots: []types.Dot{
+			{X: 15, Y: 10},
+			{X: 16, Y: 10},
+			{X: 17, Y: 10},
+			{X: 18, Y: 10},
+		},
+	}
+	wall := &types.Object{
+		Type: types.ObjectTypeWall,
+		Id:   3,
+		Dots: []types.Dot{
+			{X: 15, Y: 9},
+		},
+	}
+
+	scores, _ := scoreFixture(&profile, prey, wall)
+
+	expect := profile.Scores.Hunt + len(prey.Dots)*profile.Scores.Corpse
+	require.Equal(t, expect, scores.AccessDefault(types.Dot{X: 15, Y: 10}, 0))
+	require.Equal(t, expect, scores.AccessDefault(types.Dot{X: 14, Y: 10}, 0))
+	require.Equal(t, expect, scores.AccessDefault(types.Dot{X: 15, Y: 11}, 0))
+	// The body of the prey and the walls around are still obstacles.
+	require.Equal(t, profile.Scores.Collapse,
+		scores.AccessDefault(types.Dot{X: 16, Y: 10}, 0))
+	require.Equal(t, profile.Scores.Collapse,
+		scores.AccessDefault(types.Dot{X: 15, Y: 9}, 0))
+}
+
+func Test_Bot_score_PreyOfPacifist(t *testing.T) {
+	profile, ok := NewProfiles().Get("pacifist")
+	require.True(t, ok)
+	prey := &types.Object{
+		Type: types.ObjectTypeSnake,
+		Id:   2,
+		Dots: []types.Dot{
+			{X: 15, Y: 10},
+			{X: 16, Y: 10},
+			{X: 17, Y: 10},
+		},
+	}
+
+	scores, _ := scoreFixture(profile, prey)
+
+	require.Equal(t, profile.Scores.Collapse,
+		scores.AccessDefault(types.Dot{X: 15, Y: 10}, 0))
+	require.Equal(t, 0, scores.AccessDefault(types.Dot{X: 14, Y: 10}, 0))
+}
+
+func Test_Bot_score_PreyWithoutHunt(t *testing.T) {
+	profile := defaultProfile
+	profile.Scores.Hunt = 0
+	prey := &types.Object{
+		Type: types.ObjectTypeSnake,
+		Id:   2,
+		Dots: []types.Dot{
+			{X: 15, Y: 10},
+			{X: 16, Y: 10},
+			{X: 17, Y: 10},
+		},
+	}
+
+	scores, _ := scoreFixture(&profile, prey)
+
+	// The head of the prey is not passable.
+	require.Equal(t, profile.Scores.Collapse,
+		scores.AccessDefault(types.Dot{X: 15, Y: 10}, 0))
+	require.Equal(t, 0, scores.AccessDefault(types.Dot{X: 14, Y: 10}, 0))
+}
+
+func Test_Bot_score_Threat(t *testing.T) {
+	profile := defaultProfile
+	threat := &types.Object{
+		Type: types.ObjectTypeSnake,
+		Id:   2,
+		Dots: []types.Dot{
+			{X: 15, Y: 10},
+			{X: 16, Y: 10},
+			{X: 17, Y: 10},
+			{X: 18, Y: 10},
+			{X: 19, Y: 10},
+			{X: 20, Y: 10},
+		},
+		Direction: types.DirectionWest,
+	}
+	apple := &types.Object{
+		Type: types.ObjectTypeApple,
+		Id:   3,
+		Dot:  types.Dot{X: 14, Y: 10},
+	}
+
+	scores, _ := scoreFixture(&profile, threat, apple)
+
+	// The apple is in front of the threat.
+	require.Equal(t, profile.Scores.Danger,
+		scores.AccessDefault(types.Dot{X: 14, Y: 10}, 0))
+	require.Equal(t, profile.Scores.Danger,
+		scores.AccessDefault(types.Dot{X: 15, Y: 9}, 0))
+	require.Equal(t, profile.Scores.Danger,
+		scores.AccessDefault(types.Dot{X: 15, Y: 11}, 0))
+	// The head and the body are not overridden.
+	require.Equal(t, profile.Scores.Collapse,
+		scores.AccessDefault(types.Dot{X: 15, Y: 10}, 0))
+	require.Equal(t, profile.Scores.Collapse,
+		scores.AccessDefault(types.Dot{X: 16, Y: 10}, 0))
+}
+
+func Test_Bot_score_ThreatIgnored(t *testing.T) {
+	profile := defaultProfile
+	profile.Scores.Danger = 0
+	threat := &types.Object{
+		Type: types.ObjectTypeSnake,
+		Id:   2,
+		Dots: []types.Dot{
+			{X: 15, Y: 10},
+			{X: 15, Y: 11},
+			{X: 15, Y: 12},
+			{X: 15, Y: 13},
+			{X: 15, Y: 14},
+		},
+		Direction: types.DirectionNorth,
+	}
+
+	scores, _ := scoreFixture(&profile, threat)
+
+	require.Equal(t, 0, scores.AccessDefault(types.Dot{X: 15, Y: 9}, 0))
+}
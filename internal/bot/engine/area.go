@@ -115,6 +115,24 @@ func (a Area) FindDirection(from, to types.Dot) types.Direction {
 	return dirY
 }
 
+// Distance returns the Manhattan distance between two dots. As the
+// area wraps around its edges (see Navigate), the shortest way along
+// each axis is taken into account.
+func (a Area) Distance(from, to types.Dot) int {
+	return wrapDiff(from.X, to.X, a.Width) + wrapDiff(from.Y, to.Y, a.Height)
+}
+
+func wrapDiff(from, to, size uint8) int {
+	diff := int(from) - int(to)
+	if diff < 0 {
+		diff = -diff
+	}
+	if wrapped := int(size) - diff; wrapped < diff {
+		return wrapped
+	}
+	return diff
+}
+
 func (a Area) FitDistance(distance, divisor, gap uint8) uint8 {
 	if distance >= a.Width/divisor {
 		distance = a.Width / divisor
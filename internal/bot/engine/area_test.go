@@ -175,6 +175,54 @@ func Test_Area_Navigate(t *testing.T) {
 	}
 }
 
+func Test_Area_Distance(t *testing.T) {
+	const msgFormat = "%d: %s.Distance(%s, %s) = %d"
+
+	tests := []struct {
+		area   Area
+		from   types.Dot
+		to     types.Dot
+		expect int
+	}{
+		{
+			area:   NewArea(20, 10),
+			from:   types.Dot{X: 3, Y: 3},
+			to:     types.Dot{X: 3, Y: 3},
+			expect: 0,
+		},
+		{
+			area:   NewArea(20, 10),
+			from:   types.Dot{X: 3, Y: 3},
+			to:     types.Dot{X: 6, Y: 5},
+			expect: 5,
+		},
+		{
+			area:   NewArea(20, 10),
+			from:   types.Dot{X: 1, Y: 3},
+			to:     types.Dot{X: 18, Y: 3},
+			expect: 3,
+		},
+		{
+			area:   NewArea(20, 10),
+			from:   types.Dot{X: 18, Y: 9},
+			to:     types.Dot{X: 1, Y: 0},
+			expect: 4,
+		},
+		{
+			area:   NewArea(255, 255),
+			from:   types.Dot{X: 0, Y: 0},
+			to:     types.Dot{X: 254, Y: 127},
+			expect: 128,
+		},
+	}
+
+	for i, test := range tests {
+		distance := test.area.Distance(test.from, test.to)
+		assert.Equalf(t, test.expect, distance, msgFormat,
+			i+1, test.area, test.from, test.to, distance)
+	}
+}
+
 func Test_Area_FindDirection(t *testing.T) {
 	const msgFormat = "%d: %s.FindDirection(%s, %s) = %s"
 
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+// aStarMaxTiers limits how many groups of equally scored targets
+// the discoverer tries before giving up. Every group costs one
+// search, so the limit bounds the CPU time spent on a single tick.
+const aStarMaxTiers = 3
+
+var _ Discoverer = (*AStarDiscoverer)(nil)
+
+// AStarDiscoverer discovers paths to the highest-scoring dots within
+// the sight. It is based on the A* search algorithm. The heuristic is
+// the Manhattan distance which respects the wrap-around of the area.
+//
+// Unlike DijkstrasDiscoverer it doesn't explore every seen dot: the
+// search is directed towards the targets and stops as soon as one of
+// them is reached.
+//
+// Link: https://en.wikipedia.org/wiki/A*_search_algorithm
+type AStarDiscoverer struct {
+	// survival is optional. If it is set, the targets in dead
+	// ends are skipped.
+	survival *Survival
+}
+
+func NewAStarDiscoverer(survival *Survival) Discoverer {
+	return &AStarDiscoverer{
+		survival: survival,
+	}
+}
+
+func (d *AStarDiscoverer) Discover(head types.Dot, area Area,
+	sight Sight, scores *HashmapSight) []types.Dot {
+	for _, targets := range d.tiers(scores) {
+		if path := d.search(head, area, sight, scores, targets); len(path) > 0 {
+			return path
+		}
+	}
+	return nil
+}
+
+// tiers groups the positively scored dots by their scores. The
+// groups are sorted from the highest score to the lowest one.
+func (d *AStarDiscoverer) tiers(scores *HashmapSight) [][]types.Dot {
+	groups := make(map[int][]types.Dot)
+
+	scores.ForEach(func(dot types.Dot, v interface{}) {
+		if score, _ := v.(int); score > 0 {
+			groups[score] = append(groups[score], dot)
+		}
+	})
+
+	values := make([]int, 0, len(groups))
+	for score := range groups {
+		values = append(values, score)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(values)))
+
+	if len(values) > aStarMaxTiers {
+		values = values[:aStarMaxTiers]
+	}
+
+	tiers := make([][]types.Dot, 0, len(values))
+	for _, score := range values {
+		tiers = append(tiers, groups[score])
+	}
+	return tiers
+}
+
+func (d *AStarDiscoverer) search(head types.Dot, area Area, sight Sight,
+	scores *HashmapSight, targets []types.Dot) []types.Dot {
+	isTarget := make(map[types.Dot]struct{}, len(targets))
+	for _, target := range targets {
+		isTarget[target] = struct{}{}
+	}
+
+	estimate := func(dot types.Dot) int {
+		best := -1
+		for _, target := range targets {
+			if distance := area.Distance(dot, target); best < 0 || distance < best {
+				best = distance
+			}
+		}
+		return best
+	}
+
+	costs := map[types.Dot]int{
+		head: 0,
+	}
+	closed := make(map[types.Dot]struct{})
+	prev := make(map[types.Dot]types.Dot)
+
+	queue := &aStarQueue{}
+	heap.Push(queue, &aStarNode{
+		dot:      head,
+		cost:     0,
+		estimate: estimate(head),
+	})
+
+	for queue.Len() > 0 {
+		node := heap.Pop(queue).(*aStarNode)
+		if _, ok := closed[node.dot]; ok {
+			continue
+		}
+		closed[node.dot] = struct{}{}
+
+		if _, ok := isTarget[node.dot]; ok && node.dot != head {
+			path := backtrack(head, node.dot, node.cost, prev)
+			if d.survival == nil || d.survival.Check(area, sight, scores, path) {
+				return path
+			}
+		}
+
+		cost := node.cost + 1
+		if cost >= maxPathLength {
+			continue
+		}
+
+		for _, dot := range area.Navigate(node.dot) {
+			if !sight.Seen(dot) {
+				continue
+			}
+			if _, ok := closed[dot]; ok {
+				continue
+			}
+			if score, _ := scores.AccessDefault(dot, 0).(int); score < 0 {
+				continue
+			}
+			if known, ok := costs[dot]; ok && known <= cost {
+				continue
+			}
+
+			costs[dot] = cost
+			prev[dot] = node.dot
+			heap.Push(queue, &aStarNode{
+				dot:      dot,
+				cost:     cost,
+				estimate: estimate(dot),
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+func TestAStarDiscoverer_Discover_ReachesTarget(t *testing.T) {
+	a := NewArea(20, 20)
+	head := types.Dot{X: 5, Y: 5}
+	s := NewSight(a, head, 10)
+	scores := NewHashmapSight(s)
+
+	target := types.Dot{X: 8, Y: 7}
+	scores.Assign(target, 5)
+
+	path := NewAStarDiscoverer(nil).Discover(head, a, s, scores)
+
+	require.Len(t, path, a.Distance(head, target))
+	require.Equal(t, target, path[len(path)-1])
+}
+
+func TestAStarDiscoverer_Discover_WrapsAround(t *testing.T) {
+	a := NewArea(20, 20)
+	head := types.Dot{X: 1, Y: 10}
+	s := NewSight(a, head, 10)
+	scores := NewHashmapSight(s)
+
+	target := types.Dot{X: 18, Y: 10}
+	scores.Assign(target, 1)
+
+	path := NewAStarDiscoverer(nil).Discover(head, a, s, scores)
+
+	require.Equal(t, []types.Dot{
+		{X: 0, Y: 10},
+		{X: 19, Y: 10},
+		{X: 18, Y: 10},
+	}, path)
+}
+
+func TestAStarDiscoverer_Discover_PrefersHighestScore(t *testing.T) {
+	a := NewArea(30, 30)
+	head := types.Dot{X: 10, Y: 10}
+	s := NewSight(a, head, 14)
+	scores := NewHashmapSight(s)
+
+	scores.Assign(types.Dot{X: 11, Y: 10}, 1)
+	mouse := types.Dot{X: 10, Y: 17}
+	scores.Assign(mouse, 15)
+
+	path := NewAStarDiscoverer(nil).Discover(head, a, s, scores)
+
+	require.Len(t, path, 7)
+	require.Equal(t, mouse, path[len(path)-1])
+}
+
+func TestAStarDiscoverer_Discover_AvoidsCollapses(t *testing.T) {
+	a := NewArea(20, 20)
+	head := types.Dot{X: 5, Y: 5}
+	s := NewSight(a, head, 10)
+	scores := NewHashmapSight(s)
+
+	// A wall between the head and the target.
+	for y := uint8(2); y <= 8; y++ {
+		scores.Assign(types.Dot{X: 6, Y: y}, -1000)
+	}
+	target := types.Dot{X: 7, Y: 5}
+	scores.Assign(target, 1)
+
+	path := NewAStarDiscoverer(nil).Discover(head, a, s, scores)
+
+	require.NotEmpty(t, path)
+	require.Equal(t, target, path[len(path)-1])
+	for _, dot := range path {
+		score, _ := scores.AccessDefault(dot, 0).(int)
+		require.GreaterOrEqual(t, score, 0, dot)
+	}
+}
+
+func TestAStarDiscoverer_Discover_FallsBackToLowerTier(t *testing.T) {
+	a := NewArea(20, 20)
+	head := types.Dot{X: 5, Y: 5}
+	s := NewSight(a, head, 10)
+	scores := NewHashmapSight(s)
+
+	// The mouse is walled in.
+	mouse := types.Dot{X: 10, Y: 10}
+	scores.Assign(mouse, 15)
+	for _, dot := range a.Navigate(mouse) {
+		scores.Assign(dot, -1000)
+	}
+	apple := types.Dot{X: 5, Y: 3}
+	scores.Assign(apple, 1)
+
+	path := NewAStarDiscoverer(nil).Discover(head, a, s, scores)
+
+	require.Len(t, path, 2)
+	require.Equal(t, apple, path[1])
+}
+
+func TestAStarDiscoverer_Discover_ReturnsNothingWithoutTargets(t *testing.T) {
+	a := NewArea(20, 20)
+	head := types.Dot{X: 5, Y: 5}
+	s := NewSight(a, head, 10)
+	scores := NewHashmapSight(s)
+
+	path := NewAStarDiscoverer(nil).Discover(head, a, s, scores)
+
+	require.Empty(t, path)
+}
+
+func Benchmark_AStarDiscoverer_Discover(b *testing.B) {
+	a := NewArea(255, 255)
+	head := types.Dot{X: 100, Y: 100}
+	s := NewSight(a, head, 50)
+	scores := NewHashmapSight(s)
+	scores.Assign(types.Dot{X: 140, Y: 130}, 5)
+
+	d := NewAStarDiscoverer(nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		d.Discover(head, a, s, scores)
+	}
+}
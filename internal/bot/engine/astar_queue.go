@@ -0,0 +1,47 @@
+package engine
+
+import "github.com/ivan1993spb/snake-bot/internal/types"
+
+type aStarNode struct {
+	dot types.Dot
+	// cost is the number of steps from the head to the dot.
+	cost int
+	// estimate is a heuristic distance from the dot to the
+	// closest target.
+	estimate int
+}
+
+// aStarQueue is a priority queue of nodes ordered by the sum of the
+// cost and the estimate. The node closer to a target goes first if
+// the sums are equal.
+type aStarQueue []*aStarNode
+
+func (q aStarQueue) Len() int {
+	return len(q)
+}
+
+func (q aStarQueue) Less(i, j int) bool {
+	fi := q[i].cost + q[i].estimate
+	fj := q[j].cost + q[j].estimate
+	if fi != fj {
+		return fi < fj
+	}
+	return q[i].estimate < q[j].estimate
+}
+
+func (q aStarQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *aStarQueue) Push(x interface{}) {
+	*q = append(*q, x.(*aStarNode))
+}
+
+func (q *aStarQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[0 : n-1]
+	return item
+}
@@ -10,6 +10,9 @@ var _ Discoverer = (*CacherDiscoverer)(nil)
 
 type CacherDiscoverer struct {
 	discoverer Discoverer
+	// fallback is optional. It is used when the discoverer finds
+	// nothing valuable.
+	fallback Discoverer
 
 	path   []types.Dot
 	scores []int
@@ -17,32 +20,44 @@ type CacherDiscoverer struct {
 	expected int
 }
 
-func NewCacherDiscoverer(discoverer Discoverer) Discoverer {
+func NewCacherDiscoverer(discoverer, fallback Discoverer) Discoverer {
 	return &CacherDiscoverer{
 		discoverer: discoverer,
+		fallback:   fallback,
 	}
 }
 
 func (d *CacherDiscoverer) Discover(head types.Dot, area Area,
 	sight Sight, scores *HashmapSight) []types.Dot {
 
-	d.update(head)
+	d.update(head, area)
 
 	if d.expired(scores) {
 		d.path = d.discoverer.Discover(head, area, sight, scores)
 		d.score(scores)
+
+		// A fallback path isn't valuable, so it expires and gets
+		// rediscovered on the next call.
+		if d.expected <= 0 && d.fallback != nil {
+			d.path = d.fallback.Discover(head, area, sight, scores)
+			d.score(scores)
+		}
 	}
 
 	return d.path
 }
 
-func (d *CacherDiscoverer) update(head types.Dot) {
+func (d *CacherDiscoverer) update(head types.Dot, area Area) {
 	if len(d.path) == 0 {
 		return
 	}
 	if i := d.index(head); i > -1 {
 		d.path = d.path[i+1:]
 		d.scores = d.scores[i+1:]
+	} else if area.Distance(head, d.path[0]) != 1 {
+		// The snake has left the path.
+		d.path = nil
+		d.scores = nil
 	}
 }
 
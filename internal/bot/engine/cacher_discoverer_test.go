@@ -16,7 +16,7 @@ func TestCacherDiscoverer_update_ReturnsIfPathEmpty(t *testing.T) {
 
 	head := types.Dot{X: 1, Y: 3}
 
-	d.update(head)
+	d.update(head, NewArea(10, 10))
 }
 
 func TestCacherDiscoverer_update_CutsPath(t *testing.T) {
@@ -41,7 +41,7 @@ func TestCacherDiscoverer_update_CutsPath(t *testing.T) {
 
 	head := types.Dot{X: 1, Y: 3}
 
-	d.update(head)
+	d.update(head, NewArea(10, 10))
 
 	expectPath := []types.Dot{
 		{X: 1, Y: 4},
@@ -78,8 +78,60 @@ func TestCacherDiscoverer_update_RemovesCache(t *testing.T) {
 
 	head := types.Dot{X: 1, Y: 5}
 
-	d.update(head)
+	d.update(head, NewArea(10, 10))
 
 	assert.Empty(t, d.path)
 	assert.Empty(t, d.scores)
 }
+
+func TestCacherDiscoverer_update_DropsLeftPath(t *testing.T) {
+	d := &CacherDiscoverer{
+		path: []types.Dot{
+			{X: 1, Y: 1},
+			{X: 1, Y: 2},
+		},
+		scores: []int{
+			0,
+			1,
+		},
+	}
+
+	d.update(types.Dot{X: 1, Y: 0}, NewArea(10, 10))
+	assert.Len(t, d.path, 2)
+
+	d.update(types.Dot{X: 3, Y: 0}, NewArea(10, 10))
+	assert.Empty(t, d.path)
+	assert.Empty(t, d.scores)
+}
+
+type pathDiscoverer []types.Dot
+
+func (d pathDiscoverer) Discover(types.Dot, Area, Sight, *HashmapSight) []types.Dot {
+	return d
+}
+
+func TestCacherDiscoverer_Discover_UsesFallback(t *testing.T) {
+	a := NewArea(20, 20)
+	head := types.Dot{X: 5, Y: 5}
+	s := NewSight(a, head, 10)
+	scores := NewHashmapSight(s)
+
+	fallback := pathDiscoverer{{X: 5, Y: 4}}
+	d := NewCacherDiscoverer(pathDiscoverer{}, fallback)
+
+	assert.Equal(t, []types.Dot(fallback), d.Discover(head, a, s, scores))
+}
+
+func TestCacherDiscoverer_Discover_SkipsFallbackIfValuable(t *testing.T) {
+	a := NewArea(20, 20)
+	head := types.Dot{X: 5, Y: 5}
+	s := NewSight(a, head, 10)
+	scores := NewHashmapSight(s)
+	scores.Assign(types.Dot{X: 6, Y: 5}, 1)
+
+	primary := pathDiscoverer{{X: 6, Y: 5}}
+	fallback := pathDiscoverer{{X: 5, Y: 4}}
+	d := NewCacherDiscoverer(primary, fallback)
+
+	assert.Equal(t, []types.Dot(primary), d.Discover(head, a, s, scores))
+}
@@ -3,12 +3,17 @@ package engine
 import (
 	"container/heap"
 	"math/rand"
+	"sort"
 
 	"github.com/ivan1993spb/snake-bot/internal/types"
 )
 
 const maxPathLength = 50
 
+// survivalMaxCandidates limits how many alternative paths are checked
+// when the best one turns out to be dangerous.
+const survivalMaxCandidates = 16
+
 var _ Discoverer = (*DijkstrasDiscoverer)(nil)
 
 // DijkstrasDiscoverer discoves paths. It is based on
@@ -17,11 +22,16 @@ var _ Discoverer = (*DijkstrasDiscoverer)(nil)
 // Link: https://en.wikipedia.org/wiki/Dijkstra%27s_algorithm
 type DijkstrasDiscoverer struct {
 	random *rand.Rand
+
+	// survival is optional. If it is set, the paths leading to
+	// dead ends are avoided.
+	survival *Survival
 }
 
-func NewDijkstrasDiscoverer(r *rand.Rand) Discoverer {
+func NewDijkstrasDiscoverer(r *rand.Rand, survival *Survival) Discoverer {
 	return &DijkstrasDiscoverer{
-		random: r,
+		random:   r,
+		survival: survival,
 	}
 }
 
@@ -42,8 +52,11 @@ func (d *DijkstrasDiscoverer) Discover(head types.Dot, area Area,
 	queue.Push(best)
 	heap.Init(queue)
 
+	var explored []*Position
+
 	for queue.Len() > 0 {
 		position := heap.Pop(queue).(*Position)
+		explored = append(explored, position)
 		dots := area.Navigate(position.dot)
 		d.shuffle(dots)
 
@@ -76,7 +89,40 @@ func (d *DijkstrasDiscoverer) Discover(head types.Dot, area Area,
 	}
 
 	path := backtrack(head, best.dot, best.distance, prev)
-	return path
+	if d.survival == nil || d.survival.Check(area, sight, scores, path) {
+		return path
+	}
+
+	return d.survive(head, area, sight, scores, explored, prev, path)
+}
+
+// survive looks for the most valuable safe path among the explored
+// positions. If there is no one, the fallback path is returned.
+func (d *DijkstrasDiscoverer) survive(head types.Dot, area Area,
+	sight Sight, scores *HashmapSight, explored []*Position,
+	prev map[types.Dot]types.Dot, fallback []types.Dot) []types.Dot {
+	sort.SliceStable(explored, func(i, j int) bool {
+		if explored[i].score != explored[j].score {
+			return explored[i].score > explored[j].score
+		}
+		return explored[i].distance < explored[j].distance
+	})
+
+	checked := 0
+	for _, position := range explored {
+		if position.distance == 0 {
+			continue
+		}
+		if checked++; checked > survivalMaxCandidates {
+			break
+		}
+		path := backtrack(head, position.dot, position.distance, prev)
+		if d.survival.Check(area, sight, scores, path) {
+			return path
+		}
+	}
+
+	return fallback
 }
 
 func (d *DijkstrasDiscoverer) shuffle(dots []types.Dot) {
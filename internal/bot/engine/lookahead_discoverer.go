@@ -0,0 +1,237 @@
+package engine
+
+import (
+	"math"
+	"time"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+const (
+	// lookaheadDistance is the distance between the heads within
+	// which an opponent is considered to be close.
+	lookaheadDistance = 4
+	// lookaheadMaxOpponents bounds the number of the opponents which
+	// are simulated. The search tree grows exponentially with it.
+	lookaheadMaxOpponents = 2
+	// lookaheadDepth is the maximum number of the moves to look ahead.
+	lookaheadDepth = 3
+	// lookaheadSpaceLimit bounds the flood fill which evaluates the
+	// space around the head at the leaves of the search tree.
+	lookaheadSpaceLimit = 32
+	// lookaheadCheckEvery sets how often the deadline is checked.
+	lookaheadCheckEvery = 256
+)
+
+const (
+	lookaheadDeath   = -1 << 20
+	lookaheadTrapped = -1 << 16
+)
+
+var _ Discoverer = (*LookaheadDiscoverer)(nil)
+
+// LookaheadDiscoverer is intended for close quarters combat. When
+// there are other snakes close to the head, it runs a bounded
+// expectimax search over the next moves of the snake and of the
+// opponents, which are assumed to move randomly avoiding obstacles.
+// Otherwise and if the search doesn't fit the time budget, the path
+// is discovered by the underlying discoverer.
+type LookaheadDiscoverer struct {
+	snake      *Snake
+	opponents  *Opponents
+	discoverer Discoverer
+
+	budget time.Duration
+	clock  utils.Clock
+}
+
+func NewLookaheadDiscoverer(snake *Snake, opponents *Opponents,
+	discoverer Discoverer, budget time.Duration,
+	clock utils.Clock) Discoverer {
+	return &LookaheadDiscoverer{
+		snake:      snake,
+		opponents:  opponents,
+		discoverer: discoverer,
+
+		budget: budget,
+		clock:  clock,
+	}
+}
+
+func (d *LookaheadDiscoverer) Discover(head types.Dot, area Area,
+	sight Sight, scores *HashmapSight) []types.Dot {
+	deadline := d.clock.Now().Add(d.budget)
+	path := d.discoverer.Discover(head, area, sight, scores)
+
+	body := d.snake.Dots()
+	if len(body) == 0 || body[0] != head {
+		return path
+	}
+
+	near := d.opponents.Near(area, head, lookaheadDistance,
+		lookaheadMaxOpponents)
+	if len(near) == 0 {
+		return path
+	}
+
+	search := newLookaheadSearch(area, scores, body, near, d.clock,
+		deadline)
+	moves, ok := search.run()
+	if !ok {
+		return path
+	}
+
+	// The path of the underlying discoverer is kept if it starts
+	// with one of the best moves.
+	for _, move := range moves {
+		if len(path) > 0 && path[0] == move {
+			return path
+		}
+	}
+
+	return []types.Dot{moves[0]}
+}
+
+type lookaheadSearch struct {
+	*simulation
+
+	clock    utils.Clock
+	deadline time.Time
+	nodes    int
+	expired  bool
+}
+
+func newLookaheadSearch(area Area, scores *HashmapSight,
+	body []types.Dot, opponents []*types.Object, clock utils.Clock,
+	deadline time.Time) *lookaheadSearch {
+	return &lookaheadSearch{
+		simulation: newSimulation(area, scores, body, opponents),
+
+		clock:    clock,
+		deadline: deadline,
+	}
+}
+
+// run deepens the search iteratively while it fits the deadline. It
+// returns the best moves found by the deepest completed search.
+func (s *lookaheadSearch) run() ([]types.Dot, bool) {
+	var best []types.Dot
+
+	for depth := 1; depth <= lookaheadDepth; depth++ {
+		moves := s.best(depth)
+		if s.expired {
+			break
+		}
+		best = moves
+	}
+
+	return best, len(best) > 0
+}
+
+func (s *lookaheadSearch) best(depth int) []types.Dot {
+	var (
+		moves     []types.Dot
+		bestValue = math.Inf(-1)
+	)
+
+	for _, move := range s.myMoves(s.root) {
+		value := s.expect(s.root, move, depth)
+		if s.expired {
+			return nil
+		}
+		if value > bestValue {
+			moves = moves[:0]
+			bestValue = value
+		}
+		if value == bestValue {
+			moves = append(moves, move)
+		}
+	}
+
+	return moves
+}
+
+func (s *lookaheadSearch) value(state *simState, depth int) float64 {
+	if depth == 0 {
+		return s.evaluate(state)
+	}
+
+	best := math.Inf(-1)
+	for _, move := range s.myMoves(state) {
+		if value := s.expect(state, move, depth); value > best {
+			best = value
+		}
+		if s.expired {
+			break
+		}
+	}
+	return best
+}
+
+// expect returns the expected value of the move averaged over all the
+// combinations of the opponents' moves.
+func (s *lookaheadSearch) expect(state *simState, move types.Dot,
+	depth int) float64 {
+	options := make([][]types.Dot, len(state.opponents))
+	for i := range state.opponents {
+		options[i] = s.opponentMoves(state, i)
+	}
+
+	var (
+		total float64
+		count int
+	)
+
+	combination := make([]types.Dot, len(options))
+	var walk func(i int)
+	walk = func(i int) {
+		if s.expired {
+			return
+		}
+		if i < len(options) {
+			if len(options[i]) == 0 {
+				walk(i + 1)
+				return
+			}
+			for _, dot := range options[i] {
+				combination[i] = dot
+				walk(i + 1)
+			}
+			return
+		}
+
+		s.tick()
+		next, dead := s.step(state, move, combination)
+		if dead {
+			total += float64(lookaheadDeath + state.gained)
+		} else {
+			total += s.value(next, depth-1)
+		}
+		count++
+	}
+	walk(0)
+
+	if count == 0 {
+		return math.Inf(-1)
+	}
+	return total / float64(count)
+}
+
+func (s *lookaheadSearch) tick() {
+	s.nodes++
+	if s.nodes%lookaheadCheckEvery == 0 && !s.clock.Now().Before(s.deadline) {
+		s.expired = true
+	}
+}
+
+// evaluate scores the leaves of the search tree: the gained scores and
+// the space left around the head.
+func (s *lookaheadSearch) evaluate(state *simState) float64 {
+	space := s.space(state, lookaheadSpaceLimit)
+	value := state.gained + space
+	if need := len(state.me); space < need && space < lookaheadSpaceLimit {
+		value += lookaheadTrapped
+	}
+	return float64(value)
+}
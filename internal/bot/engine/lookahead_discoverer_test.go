@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+// lookaheadFixture returns the snake moving north to an apple and an
+// opponent which moves south right towards the snake:
+//
+//	   9 10 11
+//	5     a
+//	6     o
+//	7     o
+//	8     O
+//	9     .
+//	10    H
+//	11    s
+//	12    s
+func lookaheadFixture(opponentLen int) (Area, Sight, *HashmapSight,
+	*Snake, *Opponents) {
+	a := NewArea(20, 20)
+	body := []types.Dot{
+		{X: 10, Y: 10},
+		{X: 10, Y: 11},
+		{X: 10, Y: 12},
+	}
+	s := NewSight(a, body[0], 10)
+	scores := NewHashmapSight(s)
+	for _, dot := range body {
+		scores.Assign(dot, scoreCollapse)
+	}
+
+	opponent := &types.Object{
+		Type:      types.ObjectTypeSnake,
+		Id:        2,
+		Direction: types.DirectionSouth,
+	}
+	for i := 0; i < opponentLen; i++ {
+		dot := types.Dot{X: 10, Y: uint8(8 - i)}
+		opponent.Dots = append(opponent.Dots, dot)
+		scores.Assign(dot, scoreCollapse)
+	}
+	scores.Assign(types.Dot{X: 10, Y: 5}, 5)
+
+	snake := NewSnake()
+	snake.Update(body)
+	opponents := NewOpponents()
+	opponents.Update([]*types.Object{opponent})
+
+	return a, s, scores, snake, opponents
+}
+
+func TestLookaheadDiscoverer_Discover_AvoidsHeadOn(t *testing.T) {
+	a, s, scores, snake, opponents := lookaheadFixture(3)
+	head := snake.Dots()[0]
+
+	straight := pathDiscoverer{{X: 10, Y: 9}, {X: 10, Y: 8}}
+	d := NewLookaheadDiscoverer(snake, opponents, straight, time.Second,
+		utils.NeverClock)
+
+	path := d.Discover(head, a, s, scores)
+
+	require.Len(t, path, 1)
+	require.NotEqual(t, types.Dot{X: 10, Y: 9}, path[0])
+	require.Equal(t, 1, a.Distance(head, path[0]))
+}
+
+func TestLookaheadDiscoverer_Discover_KeepsSafePath(t *testing.T) {
+	a, s, scores, snake, opponents := lookaheadFixture(3)
+	head := snake.Dots()[0]
+
+	east := pathDiscoverer{{X: 11, Y: 10}, {X: 12, Y: 10}}
+	d := NewLookaheadDiscoverer(snake, opponents, east, time.Second,
+		utils.NeverClock)
+
+	require.Equal(t, []types.Dot(east), d.Discover(head, a, s, scores))
+}
+
+func TestLookaheadDiscoverer_Discover_NoOpponentsClose(t *testing.T) {
+	a, s, scores, snake, _ := lookaheadFixture(3)
+	head := snake.Dots()[0]
+
+	straight := pathDiscoverer{{X: 10, Y: 9}, {X: 10, Y: 8}}
+	d := NewLookaheadDiscoverer(snake, NewOpponents(), straight,
+		time.Second, utils.NeverClock)
+
+	require.Equal(t, []types.Dot(straight), d.Discover(head, a, s, scores))
+}
+
+func TestLookaheadDiscoverer_Discover_OutOfTime(t *testing.T) {
+	a, s, scores, snake, opponents := lookaheadFixture(3)
+	head := snake.Dots()[0]
+
+	straight := pathDiscoverer{{X: 10, Y: 9}, {X: 10, Y: 8}}
+	d := NewLookaheadDiscoverer(snake, opponents, straight, 0,
+		utils.ImmediatelyClock)
+
+	// The first depth is too shallow to hit the deadline check, so the
+	// danger is still noticed.
+	path := d.Discover(head, a, s, scores)
+	require.Len(t, path, 1)
+	require.NotEqual(t, types.Dot{X: 10, Y: 9}, path[0])
+}
+
+func Test_Opponents_Near(t *testing.T) {
+	a := NewArea(20, 20)
+	far := &types.Object{Dots: []types.Dot{{X: 10, Y: 0}}}
+	close := &types.Object{Dots: []types.Dot{{X: 10, Y: 9}}}
+	closer := &types.Object{Dots: []types.Dot{{X: 11, Y: 11}}}
+	empty := &types.Object{}
+
+	opponents := NewOpponents()
+	opponents.Update([]*types.Object{far, close, empty, closer})
+
+	head := types.Dot{X: 10, Y: 11}
+	require.Equal(t, []*types.Object{closer, close},
+		opponents.Near(a, head, 4, 2))
+	require.Equal(t, []*types.Object{closer},
+		opponents.Near(a, head, 4, 1))
+	require.Empty(t, opponents.Near(a, head, 0, 2))
+}
+
+func BenchmarkLookaheadDiscoverer_Discover(b *testing.B) {
+	a, s, scores, snake, opponents := lookaheadFixture(6)
+	head := snake.Dots()[0]
+
+	d := NewLookaheadDiscoverer(snake, opponents, pathDiscoverer{},
+		time.Second, utils.RealClock)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Discover(head, a, s, scores)
+	}
+}
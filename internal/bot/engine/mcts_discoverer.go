@@ -0,0 +1,286 @@
+package engine
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+const (
+	// mctsMaxOpponents bounds the number of the simulated opponents.
+	mctsMaxOpponents = 8
+	// mctsRolloutDepth is the number of the moves simulated in every
+	// rollout.
+	mctsRolloutDepth = 10
+	// mctsDistance is the distance between the heads within which
+	// the opponents are simulated. Farther snakes cannot get in the
+	// way during a rollout.
+	mctsDistance = mctsRolloutDepth * 2
+	// mctsExploration is the exploration parameter of UCB1.
+	mctsExploration = math.Sqrt2
+	// mctsGainScale makes gained scores count in rewards: the gained
+	// score equal to the scale makes a half of the bonus.
+	mctsGainScale = 10
+	// mctsTolerance is how much worse than the best move the first
+	// move of the underlying discoverer's path may be to keep it.
+	mctsTolerance = 0.05
+	// mctsCheckEvery sets how often the deadline is checked.
+	mctsCheckEvery = 16
+)
+
+var _ Discoverer = (*MCTSDiscoverer)(nil)
+
+// MCTSDiscoverer is intended for crowded games. It runs Monte Carlo
+// tree search over the moves of the snake simulating random rollouts
+// of the snakes in sight. The number of the rollouts is bounded by the
+// count and by the time budget. If there are no snakes close, the path
+// is discovered by the underlying discoverer.
+//
+// Link: https://en.wikipedia.org/wiki/Monte_Carlo_tree_search
+type MCTSDiscoverer struct {
+	snake      *Snake
+	opponents  *Opponents
+	discoverer Discoverer
+
+	random   *rand.Rand
+	rollouts int
+	budget   time.Duration
+	clock    utils.Clock
+}
+
+func NewMCTSDiscoverer(snake *Snake, opponents *Opponents,
+	discoverer Discoverer, r *rand.Rand, rollouts int,
+	budget time.Duration, clock utils.Clock) Discoverer {
+	return &MCTSDiscoverer{
+		snake:      snake,
+		opponents:  opponents,
+		discoverer: discoverer,
+
+		random:   r,
+		rollouts: rollouts,
+		budget:   budget,
+		clock:    clock,
+	}
+}
+
+func (d *MCTSDiscoverer) Discover(head types.Dot, area Area,
+	sight Sight, scores *HashmapSight) []types.Dot {
+	deadline := d.clock.Now().Add(d.budget)
+	path := d.discoverer.Discover(head, area, sight, scores)
+
+	body := d.snake.Dots()
+	if len(body) == 0 || body[0] != head {
+		return path
+	}
+
+	near := d.opponents.Near(area, head, mctsDistance, mctsMaxOpponents)
+	if len(near) == 0 {
+		return path
+	}
+
+	tree := &mctsTree{
+		simulation: newSimulation(area, scores, body, near),
+		random:     d.random,
+		root:       &mctsNode{},
+	}
+	for i := 0; i < d.rollouts; i++ {
+		if i%mctsCheckEvery == 0 && i > 0 && !d.clock.Now().Before(deadline) {
+			break
+		}
+		tree.iterate()
+	}
+
+	best := tree.best()
+	if best == nil {
+		return path
+	}
+
+	if len(path) > 0 {
+		for _, child := range tree.root.children {
+			if child.move == path[0] && child.visits > 0 &&
+				child.mean() >= best.mean()-mctsTolerance {
+				return path
+			}
+		}
+	}
+
+	return []types.Dot{best.move}
+}
+
+type mctsNode struct {
+	move     types.Dot
+	visits   int
+	reward   float64
+	expanded bool
+	children []*mctsNode
+}
+
+func (n *mctsNode) mean() float64 {
+	if n.visits == 0 {
+		return 0
+	}
+	return n.reward / float64(n.visits)
+}
+
+// mctsTree is an open loop search tree: the nodes are the moves of the
+// snake, whereas the opponents' moves are sampled on every iteration.
+type mctsTree struct {
+	*simulation
+
+	random *rand.Rand
+	root   *mctsNode
+}
+
+// iterate selects a path in the tree, expands it with a new node,
+// simulates a rollout from there and propagates the reward back.
+func (t *mctsTree) iterate() {
+	var (
+		node    = t.root
+		visited = []*mctsNode{t.root}
+		current = t.simulation.root
+		reward  float64
+	)
+
+	for depth := 1; ; depth++ {
+		if !node.expanded {
+			for _, move := range t.myMoves(current) {
+				node.children = append(node.children, &mctsNode{
+					move: move,
+				})
+			}
+			node.expanded = true
+		}
+		if len(node.children) == 0 {
+			reward = mctsReward(current, depth-1, true)
+			break
+		}
+
+		child := t.selectChild(node)
+		visited = append(visited, child)
+
+		next, dead := t.step(current, child.move, t.sampleOpponents(current))
+		if dead {
+			reward = mctsReward(current, depth-1, true)
+			break
+		}
+		current = next
+
+		if child.visits == 0 {
+			reward = t.rollout(current, depth)
+			break
+		}
+		if depth >= mctsRolloutDepth {
+			reward = mctsReward(current, depth, false)
+			break
+		}
+		node = child
+	}
+
+	for _, n := range visited {
+		n.visits++
+		n.reward += reward
+	}
+}
+
+// selectChild picks a child which hasn't been visited yet or the one
+// with the highest upper confidence bound.
+func (t *mctsTree) selectChild(node *mctsNode) *mctsNode {
+	var unvisited []*mctsNode
+	for _, child := range node.children {
+		if child.visits == 0 {
+			unvisited = append(unvisited, child)
+		}
+	}
+	if len(unvisited) > 0 {
+		return unvisited[t.random.Intn(len(unvisited))]
+	}
+
+	var (
+		best      *mctsNode
+		bestBound = math.Inf(-1)
+		logVisits = math.Log(float64(node.visits))
+	)
+	for _, child := range node.children {
+		bound := child.mean() +
+			mctsExploration*math.Sqrt(logVisits/float64(child.visits))
+		if bound > bestBound {
+			best = child
+			bestBound = bound
+		}
+	}
+	return best
+}
+
+// rollout moves the snakes randomly avoiding obvious collisions until
+// the bot's snake dies or the rollout depth is reached.
+func (t *mctsTree) rollout(state *simState, depth int) float64 {
+	for ; depth < mctsRolloutDepth; depth++ {
+		moves := t.safeMoves(state)
+		if len(moves) == 0 {
+			return mctsReward(state, depth, true)
+		}
+		move := moves[t.random.Intn(len(moves))]
+
+		next, dead := t.step(state, move, t.sampleOpponents(state))
+		if dead {
+			return mctsReward(state, depth, true)
+		}
+		state = next
+	}
+	return mctsReward(state, depth, false)
+}
+
+func (t *mctsTree) safeMoves(state *simState) []types.Dot {
+	moves := t.myMoves(state)
+	safe := make([]types.Dot, 0, len(moves))
+	for _, dot := range moves {
+		if t.blocked(dot) || contains(withoutTail(state.me), dot) {
+			continue
+		}
+		safe = append(safe, dot)
+	}
+	return safe
+}
+
+func (t *mctsTree) sampleOpponents(state *simState) []types.Dot {
+	moves := make([]types.Dot, len(state.opponents))
+	for i := range state.opponents {
+		if options := t.opponentMoves(state, i); len(options) > 0 {
+			moves[i] = options[t.random.Intn(len(options))]
+		}
+	}
+	return moves
+}
+
+// best returns the most visited move.
+func (t *mctsTree) best() *mctsNode {
+	var best *mctsNode
+	for _, child := range t.root.children {
+		if child.visits == 0 {
+			continue
+		}
+		if best == nil || child.visits > best.visits ||
+			child.visits == best.visits && child.mean() > best.mean() {
+			best = child
+		}
+	}
+	return best
+}
+
+// mctsReward returns a reward within [0, 1]. Survival makes the first
+// half of it. The gained scores make the second half, which only the
+// snakes which survive the rollout get.
+func mctsReward(state *simState, depth int, dead bool) float64 {
+	if dead {
+		return 0.5 * float64(depth) / mctsRolloutDepth
+	}
+	reward := 0.5
+	if state.gained > 0 {
+		gained := float64(state.gained)
+		reward += 0.5 * gained / (gained + mctsGainScale)
+	}
+	return reward
+}
@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+func TestMCTSDiscoverer_Discover_AvoidsHeadOn(t *testing.T) {
+	a, s, scores, snake, opponents := lookaheadFixture(3)
+	head := snake.Dots()[0]
+
+	straight := pathDiscoverer{{X: 10, Y: 9}, {X: 10, Y: 8}}
+	r := rand.New(rand.NewSource(1))
+	d := NewMCTSDiscoverer(snake, opponents, straight, r, 300,
+		time.Second, utils.NeverClock)
+
+	path := d.Discover(head, a, s, scores)
+
+	require.Len(t, path, 1)
+	require.NotEqual(t, types.Dot{X: 10, Y: 9}, path[0])
+	require.Equal(t, 1, a.Distance(head, path[0]))
+}
+
+func TestMCTSDiscoverer_Discover_KeepsSafePath(t *testing.T) {
+	a, s, scores, snake, opponents := lookaheadFixture(3)
+	head := snake.Dots()[0]
+
+	east := pathDiscoverer{{X: 11, Y: 10}, {X: 12, Y: 10}}
+	r := rand.New(rand.NewSource(1))
+	d := NewMCTSDiscoverer(snake, opponents, east, r, 300, time.Second,
+		utils.NeverClock)
+
+	require.Equal(t, []types.Dot(east), d.Discover(head, a, s, scores))
+}
+
+func TestMCTSDiscoverer_Discover_NoOpponentsClose(t *testing.T) {
+	a, s, scores, snake, _ := lookaheadFixture(3)
+	head := snake.Dots()[0]
+
+	straight := pathDiscoverer{{X: 10, Y: 9}, {X: 10, Y: 8}}
+	r := rand.New(rand.NewSource(1))
+	d := NewMCTSDiscoverer(snake, NewOpponents(), straight, r, 300,
+		time.Second, utils.NeverClock)
+
+	require.Equal(t, []types.Dot(straight), d.Discover(head, a, s, scores))
+}
+
+func TestMCTSDiscoverer_Discover_NoRollouts(t *testing.T) {
+	a, s, scores, snake, opponents := lookaheadFixture(3)
+	head := snake.Dots()[0]
+
+	straight := pathDiscoverer{{X: 10, Y: 9}, {X: 10, Y: 8}}
+	r := rand.New(rand.NewSource(1))
+	d := NewMCTSDiscoverer(snake, opponents, straight, r, 0, time.Second,
+		utils.NeverClock)
+
+	require.Equal(t, []types.Dot(straight), d.Discover(head, a, s, scores))
+}
+
+func Test_mctsReward(t *testing.T) {
+	require.Equal(t, 0.0, mctsReward(&simState{}, 0, true))
+	require.Equal(t, 0.25, mctsReward(&simState{gained: 5},
+		mctsRolloutDepth/2, true))
+	require.Equal(t, 0.5, mctsReward(&simState{}, mctsRolloutDepth, false))
+	require.Equal(t, 0.75, mctsReward(&simState{gained: mctsGainScale},
+		mctsRolloutDepth, false))
+}
+
+func BenchmarkMCTSDiscoverer_Discover(b *testing.B) {
+	a, s, scores, snake, opponents := lookaheadFixture(6)
+	head := snake.Dots()[0]
+
+	r := rand.New(rand.NewSource(1))
+	d := NewMCTSDiscoverer(snake, opponents, pathDiscoverer{}, r, 300,
+		time.Second, utils.RealClock)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Discover(head, a, s, scores)
+	}
+}
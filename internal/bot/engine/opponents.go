@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+// Opponents keeps the other snakes seen by a bot. Like Snake, it is
+// updated by the bot on every tick before discovering a path.
+type Opponents struct {
+	snakes []*types.Object
+}
+
+func NewOpponents() *Opponents {
+	return &Opponents{}
+}
+
+func (o *Opponents) Update(snakes []*types.Object) {
+	o.snakes = snakes
+}
+
+func (o *Opponents) Snakes() []*types.Object {
+	return o.snakes
+}
+
+// Near returns up to limit snakes whose heads are within the distance
+// from the dot. The closest snakes go first.
+func (o *Opponents) Near(area Area, dot types.Dot, distance,
+	limit int) []*types.Object {
+	type near struct {
+		snake    *types.Object
+		distance int
+	}
+
+	found := make([]near, 0, len(o.snakes))
+	for _, snake := range o.snakes {
+		if len(snake.Dots) == 0 {
+			continue
+		}
+		if d := area.Distance(dot, snake.Dots[0]); d <= distance {
+			found = append(found, near{snake, d})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].distance < found[j].distance
+	})
+
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	snakes := make([]*types.Object, len(found))
+	for i := range found {
+		snakes[i] = found[i].snake
+	}
+	return snakes
+}
@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+// navigateDirections are the directions of the dots returned by
+// Area.Navigate in the same order.
+var navigateDirections = [...]types.Direction{
+	types.DirectionNorth,
+	types.DirectionSouth,
+	types.DirectionEast,
+	types.DirectionWest,
+}
+
+var oppositeDirections = map[types.Direction]types.Direction{
+	types.DirectionNorth: types.DirectionSouth,
+	types.DirectionSouth: types.DirectionNorth,
+	types.DirectionEast:  types.DirectionWest,
+	types.DirectionWest:  types.DirectionEast,
+}
+
+// NextHeads returns the dots where the head of the snake can be on the
+// next tick. A snake cannot turn back, so the dot behind the head is
+// excluded. If the snake's direction is unknown, the neck of the snake
+// is excluded instead.
+func NextHeads(area Area, snake *types.Object) []types.Dot {
+	if len(snake.Dots) == 0 {
+		return nil
+	}
+
+	head := snake.Dots[0]
+	dots := area.Navigate(head)
+	heads := make([]types.Dot, 0, len(dots))
+
+	back, ok := oppositeDirections[snake.Direction]
+	for i, dot := range dots {
+		if ok && navigateDirections[i] == back {
+			continue
+		}
+		if !ok && len(snake.Dots) > 1 && dot == snake.Dots[1] {
+			continue
+		}
+		heads = append(heads, dot)
+	}
+
+	return heads
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+func Test_NextHeads(t *testing.T) {
+	a := NewArea(10, 10)
+
+	tests := []struct {
+		name   string
+		snake  *types.Object
+		expect []types.Dot
+	}{
+		{
+			name: "moves north",
+			snake: &types.Object{
+				Dots: []types.Dot{
+					{X: 5, Y: 5},
+					{X: 5, Y: 6},
+				},
+				Direction: types.DirectionNorth,
+			},
+			expect: []types.Dot{
+				{X: 5, Y: 4},
+				{X: 6, Y: 5},
+				{X: 4, Y: 5},
+			},
+		},
+		{
+			name: "moves west over the edge",
+			snake: &types.Object{
+				Dots: []types.Dot{
+					{X: 0, Y: 5},
+					{X: 1, Y: 5},
+				},
+				Direction: types.DirectionWest,
+			},
+			expect: []types.Dot{
+				{X: 0, Y: 4},
+				{X: 0, Y: 6},
+				{X: 9, Y: 5},
+			},
+		},
+		{
+			name: "unknown direction",
+			snake: &types.Object{
+				Dots: []types.Dot{
+					{X: 5, Y: 5},
+					{X: 4, Y: 5},
+				},
+			},
+			expect: []types.Dot{
+				{X: 5, Y: 4},
+				{X: 5, Y: 6},
+				{X: 6, Y: 5},
+			},
+		},
+		{
+			name: "single dot",
+			snake: &types.Object{
+				Dots: []types.Dot{
+					{X: 5, Y: 5},
+				},
+			},
+			expect: a.Navigate(types.Dot{X: 5, Y: 5}),
+		},
+		{
+			name:   "no dots",
+			snake:  &types.Object{},
+			expect: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, NextHeads(a, tt.snake))
+		})
+	}
+}
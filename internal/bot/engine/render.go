@@ -0,0 +1,206 @@
+package engine
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+// Cells of an ASCII rendering. An object is drawn over the path, the path
+// is drawn over the scores.
+const (
+	renderEmpty         = '.'
+	renderPositive      = '+'
+	renderNegative      = '-'
+	renderPath          = '*'
+	renderSnake         = 's'
+	renderApple         = 'a'
+	renderCorpse        = 'c'
+	renderMouse         = 'm'
+	renderWatermelon    = 'w'
+	renderWall          = '#'
+	renderUnknownObject = '?'
+)
+
+var renderObjects = map[types.ObjectType]byte{
+	types.ObjectTypeSnake:      renderSnake,
+	types.ObjectTypeApple:      renderApple,
+	types.ObjectTypeCorpse:     renderCorpse,
+	types.ObjectTypeMouse:      renderMouse,
+	types.ObjectTypeWatermelon: renderWatermelon,
+	types.ObjectTypeWall:       renderWall,
+}
+
+// Rendering is what a bot sees and decides on a tick: the objects
+// around, the scores of the dots and the path. The objects and
+// the scores may be nil.
+type Rendering struct {
+	Sight   Sight
+	Objects *HashmapSight
+	Scores  *HashmapSight
+	Path    []types.Dot
+}
+
+func (r *Rendering) object(dot types.Dot) (*types.Object, bool) {
+	if r.Objects == nil {
+		return nil, false
+	}
+	v, ok := r.Objects.Access(dot)
+	if !ok {
+		return nil, false
+	}
+	object, ok := v.(*types.Object)
+	return object, ok
+}
+
+func (r *Rendering) score(dot types.Dot) int {
+	if r.Scores == nil {
+		return 0
+	}
+	score, _ := r.Scores.AccessDefault(dot, 0).(int)
+	return score
+}
+
+func (r *Rendering) path() map[types.Dot]struct{} {
+	path := make(map[types.Dot]struct{}, len(r.Path))
+	for _, dot := range r.Path {
+		path[dot] = struct{}{}
+	}
+	return path
+}
+
+// ASCII renders the sight as lines of characters, the top line first.
+// It is meant for logs and golden files of tests.
+func (r *Rendering) ASCII() string {
+	width, height := r.Sight.Size()
+	path := r.path()
+
+	var sb strings.Builder
+	sb.Grow((width + 1) * height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			dot := r.Sight.Absolute(uint8(x), uint8(y))
+			sb.WriteByte(r.cell(dot, path))
+		}
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+func (r *Rendering) cell(dot types.Dot, path map[types.Dot]struct{}) byte {
+	if object, ok := r.object(dot); ok {
+		if c, ok := renderObjects[object.Type]; ok {
+			return c
+		}
+		return renderUnknownObject
+	}
+	if _, ok := path[dot]; ok {
+		return renderPath
+	}
+	if score := r.score(dot); score > 0 {
+		return renderPositive
+	} else if score < 0 {
+		return renderNegative
+	}
+	return renderEmpty
+}
+
+// renderCellSize is the size of a dot in a PNG rendering in pixels.
+const renderCellSize = 12
+
+var (
+	renderColorBackground = color.RGBA{0x20, 0x20, 0x20, 0xff}
+	renderColorPath       = color.RGBA{0xff, 0xd7, 0x00, 0xff}
+	renderColorUnknown    = color.RGBA{0xff, 0x00, 0xff, 0xff}
+
+	renderColorObjects = map[types.ObjectType]color.RGBA{
+		types.ObjectTypeSnake:      {0x3d, 0x8e, 0xf0, 0xff},
+		types.ObjectTypeApple:      {0xe0, 0x30, 0x30, 0xff},
+		types.ObjectTypeCorpse:     {0x9b, 0x6b, 0x43, 0xff},
+		types.ObjectTypeMouse:      {0xc0, 0xc0, 0xc0, 0xff},
+		types.ObjectTypeWatermelon: {0x2e, 0xb8, 0x57, 0xff},
+		types.ObjectTypeWall:       {0x80, 0x80, 0x80, 0xff},
+	}
+)
+
+// PNG renders the sight as an image. The scores tint the dots green if
+// they are positive and red if they are negative, the brighter the
+// greater the score is. Objects fill their dots and the path is drawn
+// as squares in the middle of its dots.
+func (r *Rendering) PNG(w io.Writer) error {
+	width, height := r.Sight.Size()
+	img := image.NewRGBA(image.Rect(0, 0,
+		width*renderCellSize, height*renderCellSize))
+
+	maxScore := 1
+	if r.Scores != nil {
+		r.Scores.ForEach(func(dot types.Dot, v interface{}) {
+			if score, ok := v.(int); ok {
+				if score < 0 {
+					score = -score
+				}
+				if score > maxScore {
+					maxScore = score
+				}
+			}
+		})
+	}
+
+	path := r.path()
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			dot := r.Sight.Absolute(uint8(x), uint8(y))
+			rect := image.Rect(x*renderCellSize, y*renderCellSize,
+				(x+1)*renderCellSize, (y+1)*renderCellSize)
+
+			fill(img, rect, scoreColor(r.score(dot), maxScore))
+
+			if object, ok := r.object(dot); ok {
+				c, ok := renderColorObjects[object.Type]
+				if !ok {
+					c = renderColorUnknown
+				}
+				fill(img, rect.Inset(1), c)
+			}
+
+			if _, ok := path[dot]; ok {
+				fill(img, rect.Inset(renderCellSize/3), renderColorPath)
+			}
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+func scoreColor(score, maxScore int) color.RGBA {
+	c := renderColorBackground
+	if score == 0 {
+		return c
+	}
+
+	tint := func(base uint8, score int) uint8 {
+		return base + uint8((0xff-int(base))*score/maxScore)
+	}
+
+	if score > 0 {
+		c.G = tint(c.G, score)
+	} else {
+		c.R = tint(c.R, -score)
+	}
+	return c
+}
+
+func fill(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"bytes"
+	"flag"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files")
+
+func requireGolden(t *testing.T, name, actual string) {
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0644))
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), actual)
+}
+
+// renderFixture is a snake in the top left corner of a map which
+// the sight of the snake wraps around.
+func renderFixture() *Rendering {
+	area := NewArea(20, 20)
+	m := NewMap(area)
+	m.SaveObject(&types.Object{
+		Type: types.ObjectTypeSnake,
+		Id:   1,
+		Dots: []types.Dot{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 1, Y: 3}},
+	})
+	m.SaveObject(&types.Object{
+		Type: types.ObjectTypeApple,
+		Id:   2,
+		Dot:  types.Dot{X: 3, Y: 0},
+	})
+	m.SaveObject(&types.Object{
+		Type: types.ObjectTypeWall,
+		Id:   3,
+		Dots: []types.Dot{{X: 18, Y: 0}, {X: 18, Y: 1}, {X: 18, Y: 2}},
+	})
+	m.SaveObject(&types.Object{
+		Type: types.ObjectTypeMouse,
+		Id:   4,
+		Dot:  types.Dot{X: 2, Y: 18},
+	})
+
+	sight := NewSight(area, types.Dot{X: 1, Y: 1}, 3)
+	objects := m.LookAround(sight)
+	scores := objects.Reflect()
+	scores.Assign(types.Dot{X: 3, Y: 0}, 10)
+	scores.Assign(types.Dot{X: 2, Y: 0}, 5)
+	scores.Assign(types.Dot{X: 2, Y: 18}, 3)
+	scores.Assign(types.Dot{X: 18, Y: 0}, -10)
+	scores.Assign(types.Dot{X: 19, Y: 1}, -2)
+
+	return &Rendering{
+		Sight:   sight,
+		Objects: objects,
+		Scores:  scores,
+		Path: []types.Dot{
+			{X: 1, Y: 0},
+			{X: 2, Y: 0},
+			{X: 3, Y: 0},
+		},
+	}
+}
+
+func Test_Rendering_ASCII(t *testing.T) {
+	requireGolden(t, "render.txt", renderFixture().ASCII())
+}
+
+func Test_Rendering_ASCII_Empty(t *testing.T) {
+	r := &Rendering{
+		Sight: NewSight(NewArea(10, 10), types.Dot{X: 5, Y: 5}, 1),
+	}
+	require.Equal(t, "...\n...\n...\n", r.ASCII())
+}
+
+func Test_Rendering_PNG(t *testing.T) {
+	r := renderFixture()
+
+	var buf bytes.Buffer
+	require.NoError(t, r.PNG(&buf))
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+
+	width, height := r.Sight.Size()
+	bounds := img.Bounds()
+	require.Equal(t, width*renderCellSize, bounds.Dx())
+	require.Equal(t, height*renderCellSize, bounds.Dy())
+
+	// The middle of the dot at the top left corner of the sight.
+	const middle = renderCellSize / 2
+	require.Equal(t, renderColorBackground, img.At(middle, middle))
+
+	// The apple on the path.
+	x, y := r.Sight.Relative(types.Dot{X: 3, Y: 0})
+	require.Equal(t, renderColorPath,
+		img.At(int(x)*renderCellSize+middle, int(y)*renderCellSize+middle))
+	require.Equal(t, renderColorObjects[types.ObjectTypeApple],
+		img.At(int(x)*renderCellSize+1, int(y)*renderCellSize+1))
+}
@@ -78,6 +78,16 @@ func NewSight(a Area, pos types.Dot, distance uint8) Sight {
 	}
 }
 
+// TopLeft returns the top left dot of the sight.
+func (s Sight) TopLeft() types.Dot {
+	return s.topLeft
+}
+
+// Size returns the width and the height of the sight.
+func (s Sight) Size() (int, int) {
+	return int(s.zeroedBottomRight.X) + 1, int(s.zeroedBottomRight.Y) + 1
+}
+
 func (s Sight) Absolute(relX, relY uint8) types.Dot {
 	x := uint16(s.topLeft.X) + uint16(relX)
 	y := uint16(s.topLeft.Y) + uint16(relY)
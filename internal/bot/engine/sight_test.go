@@ -407,3 +407,17 @@ func Test_Sight_DotsDoesntRepeat(t *testing.T) {
 		check[dot] = struct{}{}
 	}
 }
+
+func Test_Sight_TopLeft_Size(t *testing.T) {
+	a := Area{
+		Width:  100,
+		Height: 50,
+	}
+	s := NewSight(a, types.Dot{X: 1, Y: 13}, 3)
+
+	assert.Equal(t, types.Dot{X: 98, Y: 10}, s.TopLeft())
+	width, height := s.Size()
+	assert.Equal(t, 7, width)
+	assert.Equal(t, 7, height)
+	assert.Len(t, s.Dots(), width*height)
+}
@@ -0,0 +1,244 @@
+package engine
+
+import (
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+// simState is a state of the simulated snakes. Dead opponents have no
+// dots.
+type simState struct {
+	me        []types.Dot
+	opponents [][]types.Dot
+	// directions of the opponents are only known at the root.
+	directions []types.Direction
+
+	eaten  []types.Dot
+	gained int
+}
+
+// simulation moves the bot's snake and the given opponents within the
+// scored sight. The other objects stay still.
+type simulation struct {
+	area   Area
+	scores *HashmapSight
+	// dynamic contains the dots which are occupied by the simulated
+	// snakes or are dangerous because of them. Their scores are
+	// ignored as the snakes are simulated explicitly.
+	dynamic map[types.Dot]struct{}
+	root    *simState
+}
+
+func newSimulation(area Area, scores *HashmapSight, body []types.Dot,
+	opponents []*types.Object) *simulation {
+	dynamic := make(map[types.Dot]struct{})
+	for _, dot := range body {
+		dynamic[dot] = struct{}{}
+	}
+
+	root := &simState{
+		me:         body,
+		opponents:  make([][]types.Dot, len(opponents)),
+		directions: make([]types.Direction, len(opponents)),
+	}
+	for i, opponent := range opponents {
+		root.opponents[i] = opponent.Dots
+		root.directions[i] = opponent.Direction
+		for _, dot := range opponent.Dots {
+			dynamic[dot] = struct{}{}
+		}
+		for _, dot := range NextHeads(area, opponent) {
+			dynamic[dot] = struct{}{}
+		}
+	}
+
+	return &simulation{
+		area:    area,
+		scores:  scores,
+		dynamic: dynamic,
+		root:    root,
+	}
+}
+
+func (s *simulation) myMoves(state *simState) []types.Dot {
+	return withoutNeck(s.area.Navigate(state.me[0]), state.me)
+}
+
+// opponentMoves returns the moves of the opponent which don't lead to
+// obvious collisions. If there are no such moves, the opponent moves
+// anywhere.
+func (s *simulation) opponentMoves(state *simState,
+	i int) []types.Dot {
+	body := state.opponents[i]
+	if len(body) == 0 {
+		return nil
+	}
+
+	moves := NextHeads(s.area, &types.Object{
+		Dots:      body,
+		Direction: state.directions[i],
+	})
+
+	safe := make([]types.Dot, 0, len(moves))
+	for _, dot := range moves {
+		if s.blocked(dot) || contains(withoutTail(state.me), dot) {
+			continue
+		}
+		safe = append(safe, dot)
+	}
+	if len(safe) > 0 {
+		return safe
+	}
+	return moves
+}
+
+// step moves all the snakes simultaneously. It returns true if the
+// bot's snake dies.
+func (s *simulation) step(state *simState, move types.Dot,
+	moves []types.Dot) (*simState, bool) {
+	next := &simState{
+		me:         shift(state.me, move),
+		opponents:  make([][]types.Dot, len(state.opponents)),
+		directions: make([]types.Direction, len(state.opponents)),
+		eaten:      state.eaten,
+		gained:     state.gained,
+	}
+	for i, body := range state.opponents {
+		if len(body) > 0 {
+			next.opponents[i] = shift(body, moves[i])
+		}
+	}
+
+	if s.blocked(move) || contains(next.me[1:], move) {
+		return nil, true
+	}
+
+	for i, body := range next.opponents {
+		if len(body) == 0 {
+			continue
+		}
+		if contains(body[1:], move) {
+			return nil, true
+		}
+		if body[0] == move {
+			// A head-to-head collision is won by the longer snake.
+			if len(body) >= len(next.me) {
+				return nil, true
+			}
+			next.opponents[i] = nil
+		}
+	}
+
+	for i, body := range next.opponents {
+		if len(body) == 0 {
+			continue
+		}
+		head := body[0]
+		if s.blocked(head) || contains(next.me, head) {
+			next.opponents[i] = nil
+			continue
+		}
+		for j, other := range next.opponents {
+			if len(other) > 0 && contains(other[1:], head) ||
+				i != j && len(other) > 0 && other[0] == head {
+				next.opponents[i] = nil
+				break
+			}
+		}
+	}
+
+	if score := s.score(move); score > 0 && !contains(state.eaten, move) {
+		next.eaten = append(append([]types.Dot(nil), state.eaten...), move)
+		next.gained += score
+	}
+
+	return next, false
+}
+
+// space counts the free dots reachable from the head of the snake up
+// to the limit.
+func (s *simulation) space(state *simState, limit int) int {
+	occupied := make(map[types.Dot]struct{})
+	for _, dot := range state.me {
+		occupied[dot] = struct{}{}
+	}
+	for _, body := range state.opponents {
+		for _, dot := range body {
+			occupied[dot] = struct{}{}
+		}
+	}
+
+	space := 0
+	visited := map[types.Dot]struct{}{
+		state.me[0]: {},
+	}
+	queue := []types.Dot{state.me[0]}
+	for len(queue) > 0 && space < limit {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dot := range s.area.Navigate(current) {
+			if _, ok := visited[dot]; ok {
+				continue
+			}
+			visited[dot] = struct{}{}
+			if _, ok := occupied[dot]; ok || s.blocked(dot) {
+				continue
+			}
+			if space++; space == limit {
+				break
+			}
+			queue = append(queue, dot)
+		}
+	}
+
+	return space
+}
+
+func (s *simulation) score(dot types.Dot) int {
+	score, _ := s.scores.AccessDefault(dot, 0).(int)
+	return score
+}
+
+// blocked returns true if the dot is occupied by an obstacle which
+// isn't simulated.
+func (s *simulation) blocked(dot types.Dot) bool {
+	if _, ok := s.dynamic[dot]; ok {
+		return false
+	}
+	return s.score(dot) < 0
+}
+
+// shift moves the body one dot forward without growing.
+func shift(body []types.Dot, head types.Dot) []types.Dot {
+	next := make([]types.Dot, 0, len(body))
+	next = append(next, head)
+	return append(next, body[:len(body)-1]...)
+}
+
+func withoutNeck(dots, body []types.Dot) []types.Dot {
+	if len(body) < 2 {
+		return dots
+	}
+	moves := make([]types.Dot, 0, len(dots))
+	for _, dot := range dots {
+		if dot != body[1] {
+			moves = append(moves, dot)
+		}
+	}
+	return moves
+}
+
+func withoutTail(body []types.Dot) []types.Dot {
+	if len(body) == 0 {
+		return body
+	}
+	return body[:len(body)-1]
+}
+
+func contains(dots []types.Dot, dot types.Dot) bool {
+	for _, d := range dots {
+		if d == dot {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package engine
+
+import "github.com/ivan1993spb/snake-bot/internal/types"
+
+// Snake keeps the current body of the snake controlled by a bot. The
+// bot updates it on every tick before discovering a path, so the
+// engine components which need to know more than the position of the
+// head can consult it.
+type Snake struct {
+	dots []types.Dot
+}
+
+func NewSnake() *Snake {
+	return &Snake{}
+}
+
+func (s *Snake) Update(dots []types.Dot) {
+	s.dots = dots
+}
+
+// Dots returns the body of the snake. The first dot is the head.
+func (s *Snake) Dots() []types.Dot {
+	return s.dots
+}
+
+func (s *Snake) Len() int {
+	return len(s.dots)
+}
@@ -0,0 +1,122 @@
+package engine
+
+import "github.com/ivan1993spb/snake-bot/internal/types"
+
+// Survival validates paths. A path is considered to be safe if the
+// snake following it ends up with enough free space around to fit
+// its whole body. Such check prevents a snake from getting into a
+// pocket which gets sealed by the snake itself.
+type Survival struct {
+	snake *Snake
+}
+
+func NewSurvival(snake *Snake) *Survival {
+	return &Survival{
+		snake: snake,
+	}
+}
+
+// Check returns true if the snake will have enough space to move on
+// after it follows the path.
+func (s *Survival) Check(area Area, sight Sight, scores *HashmapSight,
+	path []types.Dot) bool {
+	body := s.snake.Dots()
+	if len(path) == 0 || len(body) == 0 {
+		return true
+	}
+
+	return s.Space(area, sight, scores, path) >= len(body)
+}
+
+// Space returns the number of free dots reachable from the end of the
+// path when the snake gets there. The counting stops as soon as there
+// is enough space for the body of the snake. Dots beyond the sight are
+// unknown, so reaching the edge of the sight is considered enough.
+func (s *Survival) Space(area Area, sight Sight, scores *HashmapSight,
+	path []types.Dot) int {
+	body := s.snake.Dots()
+	if len(path) == 0 {
+		return floodFill(area, sight, scores, body, body, len(body))
+	}
+	return floodFill(area, sight, scores, futureBody(body, path), body,
+		len(body))
+}
+
+// futureBody returns the body of the snake after it follows the path
+// without growing.
+func futureBody(body, path []types.Dot) []types.Dot {
+	future := make([]types.Dot, 0, len(body))
+	for i := len(path) - 1; i >= 0 && len(future) < len(body); i-- {
+		future = append(future, path[i])
+	}
+	for i := 0; len(future) < len(body); i++ {
+		future = append(future, body[i])
+	}
+	return future
+}
+
+// floodFill counts the free dots reachable from the head of the body
+// up to the limit. The body blocks the dots it occupies except the
+// tail which moves away. The dots of the current body are scored as
+// collapses, but the ones the body leaves behind are free.
+func floodFill(area Area, sight Sight, scores *HashmapSight,
+	body, current []types.Dot, limit int) int {
+	if len(body) == 0 {
+		return 0
+	}
+
+	blocked := make(map[types.Dot]struct{}, len(body))
+	for _, dot := range body[:len(body)-1] {
+		blocked[dot] = struct{}{}
+	}
+	left := make(map[types.Dot]struct{}, len(current)+1)
+	for _, dot := range current {
+		left[dot] = struct{}{}
+	}
+	left[body[len(body)-1]] = struct{}{}
+
+	passable := func(dot types.Dot) bool {
+		if _, ok := blocked[dot]; ok {
+			return false
+		}
+		if _, ok := left[dot]; ok {
+			return true
+		}
+		score, _ := scores.AccessDefault(dot, 0).(int)
+		return score >= 0
+	}
+
+	head := body[0]
+	visited := map[types.Dot]struct{}{
+		head: {},
+	}
+	queue := []types.Dot{head}
+	count := 0
+
+	for len(queue) > 0 && count < limit {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, dot := range area.Navigate(current) {
+			if _, ok := visited[dot]; ok {
+				continue
+			}
+			visited[dot] = struct{}{}
+
+			if !sight.Seen(dot) {
+				return limit
+			}
+			if !passable(dot) {
+				continue
+			}
+
+			count++
+			queue = append(queue, dot)
+		}
+	}
+
+	if count > limit {
+		return limit
+	}
+	return count
+}
@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+const scoreCollapse = -1000
+
+// pocketFixture returns a sight around the snake's head and scores
+// where the head looks at a dead end with a mouse inside:
+//
+//	  9 10 11
+//	4    #
+//	5 #  m  #
+//	6    .
+//	7    .
+//	8    .
+//	9    .
+//	10   H
+//	11   s
+//	...  s
+func pocketFixture() (Area, Sight, *HashmapSight, *Snake) {
+	a := NewArea(20, 20)
+	body := []types.Dot{
+		{X: 10, Y: 10},
+		{X: 10, Y: 11},
+		{X: 10, Y: 12},
+		{X: 10, Y: 13},
+		{X: 10, Y: 14},
+		{X: 10, Y: 15},
+	}
+	s := NewSight(a, body[0], 10)
+	scores := NewHashmapSight(s)
+
+	for _, dot := range body {
+		scores.Assign(dot, scoreCollapse)
+	}
+
+	scores.Assign(types.Dot{X: 10, Y: 5}, 15)
+	scores.Assign(types.Dot{X: 9, Y: 5}, scoreCollapse)
+	scores.Assign(types.Dot{X: 11, Y: 5}, scoreCollapse)
+	scores.Assign(types.Dot{X: 10, Y: 4}, scoreCollapse)
+
+	snake := NewSnake()
+	snake.Update(body)
+
+	return a, s, scores, snake
+}
+
+func TestSurvival_Check_RejectsPocket(t *testing.T) {
+	a, s, scores, snake := pocketFixture()
+
+	path := []types.Dot{
+		{X: 10, Y: 9},
+		{X: 10, Y: 8},
+		{X: 10, Y: 7},
+		{X: 10, Y: 6},
+		{X: 10, Y: 5},
+	}
+
+	survival := NewSurvival(snake)
+	require.Equal(t, 0, survival.Space(a, s, scores, path))
+	require.False(t, survival.Check(a, s, scores, path))
+}
+
+func TestSurvival_Check_AcceptsOpenSpace(t *testing.T) {
+	a, s, scores, snake := pocketFixture()
+
+	path := []types.Dot{
+		{X: 11, Y: 10},
+		{X: 12, Y: 10},
+		{X: 13, Y: 10},
+	}
+
+	survival := NewSurvival(snake)
+	require.Equal(t, snake.Len(), survival.Space(a, s, scores, path))
+	require.True(t, survival.Check(a, s, scores, path))
+}
+
+func TestSurvival_Check_CountsDotsLeftByBody(t *testing.T) {
+	a := NewArea(20, 20)
+	// The snake is in a closed box:
+	//
+	//	  3 4 5 6 7
+	//	3 # # # # #
+	//	4 # H s s #
+	//	5 # . . s #
+	//	6 # . s s #
+	//	7 # . . . #
+	//	8 # # # # #
+	body := []types.Dot{
+		{X: 4, Y: 4},
+		{X: 5, Y: 4},
+		{X: 6, Y: 4},
+		{X: 6, Y: 5},
+		{X: 6, Y: 6},
+		{X: 5, Y: 6},
+	}
+	s := NewSight(a, body[0], 10)
+	scores := NewHashmapSight(s)
+	for _, dot := range body {
+		scores.Assign(dot, scoreCollapse)
+	}
+	for i := uint8(3); i <= 7; i++ {
+		scores.Assign(types.Dot{X: i, Y: 3}, scoreCollapse)
+		scores.Assign(types.Dot{X: i, Y: 8}, scoreCollapse)
+	}
+	for i := uint8(3); i <= 8; i++ {
+		scores.Assign(types.Dot{X: 3, Y: i}, scoreCollapse)
+		scores.Assign(types.Dot{X: 7, Y: i}, scoreCollapse)
+	}
+
+	snake := NewSnake()
+	snake.Update(body)
+	survival := NewSurvival(snake)
+
+	path := []types.Dot{
+		{X: 4, Y: 5},
+		{X: 4, Y: 6},
+	}
+
+	// Without the dots left by the body there would be only 3
+	// free dots at the bottom of the box.
+	require.Equal(t, snake.Len(), survival.Space(a, s, scores, path))
+	require.True(t, survival.Check(a, s, scores, path))
+}
+
+func TestSurvival_Check_EmptyPath(t *testing.T) {
+	a, s, scores, snake := pocketFixture()
+
+	require.True(t, NewSurvival(snake).Check(a, s, scores, nil))
+}
+
+func TestSurvival_Space_EdgeOfSight(t *testing.T) {
+	a := NewArea(40, 40)
+	body := []types.Dot{
+		{X: 20, Y: 20},
+		{X: 20, Y: 21},
+	}
+	s := NewSight(a, body[0], 1)
+	scores := NewHashmapSight(s)
+
+	snake := NewSnake()
+	snake.Update(body)
+
+	// Dots beyond the sight are unknown, so they are considered
+	// to be enough.
+	require.Equal(t, snake.Len(), NewSurvival(snake).Space(a, s, scores, nil))
+}
+
+func Test_futureBody(t *testing.T) {
+	body := []types.Dot{
+		{X: 1, Y: 1},
+		{X: 1, Y: 2},
+		{X: 1, Y: 3},
+		{X: 1, Y: 4},
+	}
+
+	require.Equal(t, []types.Dot{
+		{X: 3, Y: 1},
+		{X: 2, Y: 1},
+		{X: 1, Y: 1},
+		{X: 1, Y: 2},
+	}, futureBody(body, []types.Dot{
+		{X: 2, Y: 1},
+		{X: 3, Y: 1},
+	}))
+
+	require.Equal(t, []types.Dot{
+		{X: 6, Y: 1},
+		{X: 5, Y: 1},
+		{X: 4, Y: 1},
+		{X: 3, Y: 1},
+	}, futureBody(body, []types.Dot{
+		{X: 2, Y: 1},
+		{X: 3, Y: 1},
+		{X: 4, Y: 1},
+		{X: 5, Y: 1},
+		{X: 6, Y: 1},
+	}))
+}
+
+func TestDijkstrasDiscoverer_Discover_AvoidsPocket(t *testing.T) {
+	a, s, scores, snake := pocketFixture()
+	apple := types.Dot{X: 14, Y: 10}
+	scores.Assign(apple, 1)
+
+	head := snake.Dots()[0]
+	r := rand.New(rand.NewSource(1))
+
+	withoutSurvival := NewDijkstrasDiscoverer(r, nil).Discover(head, a, s, scores)
+	require.Equal(t, types.Dot{X: 10, Y: 5}, withoutSurvival[len(withoutSurvival)-1])
+
+	path := NewDijkstrasDiscoverer(r, NewSurvival(snake)).Discover(head, a, s, scores)
+	require.Len(t, path, 4)
+	require.Equal(t, apple, path[len(path)-1])
+}
+
+func TestAStarDiscoverer_Discover_AvoidsPocket(t *testing.T) {
+	a, s, scores, snake := pocketFixture()
+	apple := types.Dot{X: 14, Y: 10}
+	scores.Assign(apple, 1)
+
+	head := snake.Dots()[0]
+
+	path := NewAStarDiscoverer(NewSurvival(snake)).Discover(head, a, s, scores)
+	require.Len(t, path, 4)
+	require.Equal(t, apple, path[len(path)-1])
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+// openRegionLimit bounds the flood fill which measures open regions
+// around the head when the tail cannot be reached.
+const openRegionLimit = 256
+
+var _ Discoverer = (*TailDiscoverer)(nil)
+
+// TailDiscoverer is a fallback strategy for the case when there is
+// nothing valuable in sight. It leads the snake after its own tail:
+// the dot occupied by the tail is guaranteed to become free. If the
+// tail cannot be reached, the snake heads for the largest open region
+// around.
+type TailDiscoverer struct {
+	snake *Snake
+}
+
+func NewTailDiscoverer(snake *Snake) Discoverer {
+	return &TailDiscoverer{
+		snake: snake,
+	}
+}
+
+func (d *TailDiscoverer) Discover(head types.Dot, area Area,
+	sight Sight, scores *HashmapSight) []types.Dot {
+	body := d.snake.Dots()
+	if len(body) == 0 || body[0] != head {
+		return nil
+	}
+
+	if path := d.chaseTail(area, sight, scores, body); len(path) > 0 {
+		return path
+	}
+
+	return d.openRegion(area, sight, scores, body)
+}
+
+// chaseTail finds the shortest path from the head to the tail.
+func (d *TailDiscoverer) chaseTail(area Area, sight Sight,
+	scores *HashmapSight, body []types.Dot) []types.Dot {
+	head := body[0]
+	tail := body[len(body)-1]
+	if len(body) < 2 || !sight.Seen(tail) {
+		return nil
+	}
+
+	prev := make(map[types.Dot]types.Dot)
+	distances := map[types.Dot]int{
+		head: 0,
+	}
+	queue := []types.Dot{head}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		distance := distances[current] + 1
+		if distance >= maxPathLength {
+			continue
+		}
+
+		for _, dot := range area.Navigate(current) {
+			if _, ok := distances[dot]; ok || !sight.Seen(dot) {
+				continue
+			}
+			if dot == tail {
+				// The tail next to the head is followed only
+				// if the snake is long enough to turn around.
+				if distance == 1 && len(body) < 4 {
+					continue
+				}
+				prev[dot] = current
+				return backtrack(head, tail, distance, prev)
+			}
+			if score, _ := scores.AccessDefault(dot, 0).(int); score < 0 {
+				continue
+			}
+			prev[dot] = current
+			distances[dot] = distance
+			queue = append(queue, dot)
+		}
+	}
+
+	return nil
+}
+
+// openRegion returns a single step to the neighbour dot from which
+// the largest region is reachable.
+func (d *TailDiscoverer) openRegion(area Area, sight Sight,
+	scores *HashmapSight, body []types.Dot) []types.Dot {
+	var (
+		best      types.Dot
+		bestSpace = -1
+	)
+
+	for _, dot := range area.Navigate(body[0]) {
+		if score, _ := scores.AccessDefault(dot, 0).(int); score < 0 {
+			continue
+		}
+		future := futureBody(body, []types.Dot{dot})
+		space := floodFill(area, sight, scores, future, body, openRegionLimit)
+		if space > bestSpace {
+			best = dot
+			bestSpace = space
+		}
+	}
+
+	if bestSpace < 0 {
+		return nil
+	}
+	return []types.Dot{best}
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+func TestTailDiscoverer_Discover_ChasesTail(t *testing.T) {
+	a := NewArea(20, 20)
+	body := []types.Dot{
+		{X: 5, Y: 5},
+		{X: 6, Y: 5},
+		{X: 7, Y: 5},
+		{X: 7, Y: 6},
+		{X: 7, Y: 7},
+	}
+	s := NewSight(a, body[0], 10)
+	scores := NewHashmapSight(s)
+	for _, dot := range body {
+		scores.Assign(dot, scoreCollapse)
+	}
+
+	snake := NewSnake()
+	snake.Update(body)
+
+	path := NewTailDiscoverer(snake).Discover(body[0], a, s, scores)
+
+	require.Len(t, path, a.Distance(body[0], body[len(body)-1]))
+	require.Equal(t, body[len(body)-1], path[len(path)-1])
+	for _, dot := range path[:len(path)-1] {
+		require.NotContains(t, body, dot)
+	}
+}
+
+func TestTailDiscoverer_Discover_HeadsForOpenRegion(t *testing.T) {
+	a := NewArea(20, 20)
+	// The tail cannot be reached. There is a pocket to the west of
+	// the head and the open space to the east:
+	//
+	//	  2 3 4 5
+	//	3 . . # .
+	//	4 . # s #
+	//	5 # . H .
+	//	6 . # # .
+	body := []types.Dot{
+		{X: 4, Y: 5},
+		{X: 4, Y: 4},
+	}
+	s := NewSight(a, body[0], 10)
+	scores := NewHashmapSight(s)
+	for _, dot := range body {
+		scores.Assign(dot, scoreCollapse)
+	}
+	for _, dot := range []types.Dot{
+		{X: 4, Y: 3},
+		{X: 3, Y: 4}, {X: 5, Y: 4},
+		{X: 2, Y: 5},
+		{X: 3, Y: 6}, {X: 4, Y: 6},
+	} {
+		scores.Assign(dot, scoreCollapse)
+	}
+
+	snake := NewSnake()
+	snake.Update(body)
+
+	path := NewTailDiscoverer(snake).Discover(body[0], a, s, scores)
+
+	require.Equal(t, []types.Dot{{X: 5, Y: 5}}, path)
+}
+
+func TestTailDiscoverer_Discover_Trapped(t *testing.T) {
+	a := NewArea(20, 20)
+	body := []types.Dot{
+		{X: 5, Y: 5},
+		{X: 5, Y: 6},
+	}
+	s := NewSight(a, body[0], 10)
+	scores := NewHashmapSight(s)
+	for _, dot := range a.Navigate(body[0]) {
+		scores.Assign(dot, scoreCollapse)
+	}
+
+	snake := NewSnake()
+	snake.Update(body)
+
+	path := NewTailDiscoverer(snake).Discover(body[0], a, s, scores)
+
+	require.Empty(t, path)
+}
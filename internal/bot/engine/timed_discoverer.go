@@ -0,0 +1,33 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+var _ Discoverer = (*TimedDiscoverer)(nil)
+
+// TimedDiscoverer reports how long the discoverer takes to find a path.
+type TimedDiscoverer struct {
+	discoverer Discoverer
+	observe    func(time.Duration)
+}
+
+func NewTimedDiscoverer(discoverer Discoverer,
+	observe func(time.Duration)) Discoverer {
+	return &TimedDiscoverer{
+		discoverer: discoverer,
+		observe:    observe,
+	}
+}
+
+func (d *TimedDiscoverer) Discover(head types.Dot, area Area,
+	sight Sight, scores *HashmapSight) []types.Dot {
+
+	start := time.Now()
+	path := d.discoverer.Discover(head, area, sight, scores)
+	d.observe(time.Since(start))
+
+	return path
+}
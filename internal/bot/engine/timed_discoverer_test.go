@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+func TestTimedDiscoverer_Discover_ReportsDuration(t *testing.T) {
+	a := NewArea(20, 20)
+	body := []types.Dot{
+		{X: 5, Y: 5},
+		{X: 6, Y: 5},
+		{X: 7, Y: 5},
+	}
+	s := NewSight(a, body[0], 10)
+	scores := NewHashmapSight(s)
+
+	snake := NewSnake()
+	snake.Update(body)
+	tail := NewTailDiscoverer(snake)
+
+	var durations []time.Duration
+	timed := NewTimedDiscoverer(tail, func(d time.Duration) {
+		durations = append(durations, d)
+	})
+
+	path := timed.Discover(body[0], a, s, scores)
+
+	require.Equal(t, tail.Discover(body[0], a, s, scores), path)
+	require.Len(t, durations, 1)
+	require.True(t, durations[0] >= 0)
+}
@@ -0,0 +1,273 @@
+package bot
+
+import (
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultProfileName is the name of the profile bots get if no other
+// profile is requested.
+const DefaultProfileName = "default"
+
+const minTickTime = time.Millisecond * 50
+
+// Profile defines the personality of a bot: how it values the objects
+// around, how far it looks and how often it makes decisions.
+//
+// Discoverer overrides the path discovery algorithm configured for all
+// bots if it is set. Rollouts is the number of the simulations made
+// per tick by the mcts discoverer.
+//
+// Deterministic makes the lookahead and the mcts discoverers ignore the
+// time budget: the searches are bounded by the depth and by Rollouts
+// only, so bots with the same seed make the same moves whatever
+// the load of the host is.
+type Profile struct {
+	Name           string        `yaml:"name"`
+	LookupDistance uint8         `yaml:"lookup_distance"`
+	TickTime       time.Duration `yaml:"tick_time"`
+	Scores         ProfileScores `yaml:"scores"`
+	Discoverer     string        `yaml:"discoverer"`
+	Rollouts       int           `yaml:"rollouts"`
+	Deterministic  bool          `yaml:"deterministic"`
+}
+
+// ProfileScores are the weights of the objects. Negative scores make
+// bots avoid the objects. Danger is the score of the dots where the
+// heads of other snakes can be on the next tick.
+type ProfileScores struct {
+	Collapse   int `yaml:"collapse"`
+	Apple      int `yaml:"apple"`
+	Corpse     int `yaml:"corpse"`
+	Watermelon int `yaml:"watermelon"`
+	Mouse      int `yaml:"mouse"`
+	Hunt       int `yaml:"hunt"`
+	Danger     int `yaml:"danger"`
+}
+
+var defaultProfile = Profile{
+	Name:           DefaultProfileName,
+	LookupDistance: 50,
+	TickTime:       time.Millisecond * 200,
+	Scores: ProfileScores{
+		Collapse:   -1000,
+		Apple:      1,
+		Corpse:     2,
+		Watermelon: 5,
+		Mouse:      15,
+		Hunt:       30,
+		Danger:     -500,
+	},
+	Rollouts: 300,
+}
+
+var builtinProfiles = []Profile{
+	defaultProfile,
+	{
+		Name:           "greedy",
+		LookupDistance: 60,
+		TickTime:       time.Millisecond * 200,
+		Scores: ProfileScores{
+			Collapse:   -1000,
+			Apple:      3,
+			Corpse:     4,
+			Watermelon: 10,
+			Mouse:      20,
+			Hunt:       5,
+			Danger:     -300,
+		},
+		Rollouts: 300,
+	},
+	{
+		Name:           "hunter",
+		LookupDistance: 50,
+		TickTime:       time.Millisecond * 150,
+		Scores: ProfileScores{
+			Collapse:   -1000,
+			Apple:      1,
+			Corpse:     1,
+			Watermelon: 3,
+			Mouse:      10,
+			Hunt:       60,
+			Danger:     -200,
+		},
+		Rollouts: 300,
+	},
+	{
+		Name:           "coward",
+		LookupDistance: 30,
+		TickTime:       time.Millisecond * 200,
+		Scores: ProfileScores{
+			Collapse:   -1000,
+			Apple:      1,
+			Corpse:     0,
+			Watermelon: 5,
+			Mouse:      15,
+			Hunt:       -1000,
+			Danger:     -1000,
+		},
+		Rollouts: 300,
+	},
+	{
+		Name:           "pacifist",
+		LookupDistance: 50,
+		TickTime:       time.Millisecond * 200,
+		Scores: ProfileScores{
+			Collapse:   -1000,
+			Apple:      1,
+			Corpse:     2,
+			Watermelon: 5,
+			Mouse:      15,
+			Hunt:       -1000,
+			Danger:     -500,
+		},
+		Rollouts: 300,
+	},
+	{
+		Name:           "swarm",
+		LookupDistance: 30,
+		TickTime:       time.Millisecond * 200,
+		Scores: ProfileScores{
+			Collapse:   -1000,
+			Apple:      1,
+			Corpse:     2,
+			Watermelon: 5,
+			Mouse:      15,
+			Hunt:       30,
+			Danger:     -500,
+		},
+		Discoverer: DiscovererMCTS,
+		Rollouts:   500,
+	},
+}
+
+// UnmarshalYAML fills in the fields which are missing in the document
+// with the values of the default profile.
+func (p *Profile) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Profile
+	*p = defaultProfile
+	p.Name = ""
+	return unmarshal((*plain)(p))
+}
+
+func (p *Profile) validate() error {
+	if p.Name == "" {
+		return errors.New("empty profile name")
+	}
+	if p.LookupDistance == 0 {
+		return errors.Errorf("profile %q: zero lookup distance", p.Name)
+	}
+	if p.TickTime < minTickTime {
+		return errors.Errorf("profile %q: tick time is less than %s",
+			p.Name, minTickTime)
+	}
+	if p.Discoverer != "" && !IsDiscoverer(p.Discoverer) {
+		return errors.Errorf("profile %q: unknown discoverer %q",
+			p.Name, p.Discoverer)
+	}
+	if p.Rollouts < 0 {
+		return errors.Errorf("profile %q: negative number of rollouts",
+			p.Name)
+	}
+	return nil
+}
+
+func (p *Profile) behavior() map[scoreType]int {
+	return map[scoreType]int{
+		scoreTypeCollapse:       p.Scores.Collapse,
+		scoreTypeFoodApple:      p.Scores.Apple,
+		scoreTypeFoodCorpse:     p.Scores.Corpse,
+		scoreTypeFoodWatermelon: p.Scores.Watermelon,
+		scoreTypeFoodMouse:      p.Scores.Mouse,
+		scoreTypeHunt:           p.Scores.Hunt,
+		scoreTypeDanger:         p.Scores.Danger,
+	}
+}
+
+// Profiles is a set of named profiles. It is safe for concurrent use.
+type Profiles struct {
+	mux      sync.RWMutex
+	profiles map[string]*Profile
+}
+
+// NewProfiles returns the set of the built-in profiles.
+func NewProfiles() *Profiles {
+	profiles := make(map[string]*Profile, len(builtinProfiles))
+	for i := range builtinProfiles {
+		profile := builtinProfiles[i]
+		profiles[profile.Name] = &profile
+	}
+	return &Profiles{
+		profiles: profiles,
+	}
+}
+
+type profilesDocument struct {
+	Profiles []*Profile `yaml:"profiles"`
+}
+
+const errLoadProfilesAnnotation = "cannot load profiles"
+
+// Load reads profiles from a YAML document. The loaded profiles are
+// added to the set replacing the ones with the same names.
+func (p *Profiles) Load(r io.Reader) error {
+	var document profilesDocument
+	if err := yaml.NewDecoder(r).Decode(&document); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return errors.Wrap(err, errLoadProfilesAnnotation)
+	}
+
+	for _, profile := range document.Profiles {
+		if err := profile.validate(); err != nil {
+			return errors.Wrap(err, errLoadProfilesAnnotation)
+		}
+	}
+
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	for _, profile := range document.Profiles {
+		p.profiles[profile.Name] = profile
+	}
+
+	return nil
+}
+
+// Get returns the profile with the given name. An empty name stands
+// for the default profile.
+func (p *Profiles) Get(name string) (*Profile, bool) {
+	if name == "" {
+		name = DefaultProfileName
+	}
+
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+
+	profile, ok := p.profiles[name]
+	return profile, ok
+}
+
+func (p *Profiles) Has(name string) bool {
+	_, ok := p.Get(name)
+	return ok
+}
+
+// Names returns the sorted names of the profiles.
+func (p *Profiles) Names() []string {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+
+	names := make([]string, 0, len(p.profiles))
+	for name := range p.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
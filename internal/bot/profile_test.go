@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewProfiles_Builtin(t *testing.T) {
+	profiles := NewProfiles()
+
+	require.Equal(t, []string{
+		"coward",
+		"default",
+		"greedy",
+		"hunter",
+		"pacifist",
+		"swarm",
+	}, profiles.Names())
+
+	profile, ok := profiles.Get("")
+	require.True(t, ok)
+	require.Equal(t, DefaultProfileName, profile.Name)
+
+	for _, name := range profiles.Names() {
+		profile, ok := profiles.Get(name)
+		require.True(t, ok)
+		require.NoError(t, profile.validate())
+	}
+}
+
+func TestProfiles_Load(t *testing.T) {
+	const document = `
+profiles:
+- name: sprinter
+  tick_time: 100ms
+  scores:
+    apple: 7
+- name: hunter
+  lookup_distance: 20
+  deterministic: true
+`
+
+	profiles := NewProfiles()
+	require.NoError(t, profiles.Load(strings.NewReader(document)))
+
+	sprinter, ok := profiles.Get("sprinter")
+	require.True(t, ok)
+	require.Equal(t, time.Millisecond*100, sprinter.TickTime)
+	require.Equal(t, 7, sprinter.Scores.Apple)
+	// The missing fields are taken from the default profile.
+	require.Equal(t, defaultProfile.LookupDistance, sprinter.LookupDistance)
+	require.Equal(t, defaultProfile.Scores.Collapse, sprinter.Scores.Collapse)
+	require.Equal(t, defaultProfile.Scores.Mouse, sprinter.Scores.Mouse)
+
+	require.Empty(t, sprinter.Discoverer)
+	require.Equal(t, defaultProfile.Rollouts, sprinter.Rollouts)
+	require.False(t, sprinter.Deterministic)
+
+	hunter, ok := profiles.Get("hunter")
+	require.True(t, ok)
+	require.Equal(t, uint8(20), hunter.LookupDistance)
+	require.True(t, hunter.Deterministic)
+
+	require.True(t, profiles.Has("greedy"))
+	require.False(t, profiles.Has("unknown"))
+}
+
+func TestProfiles_Load_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+	}{
+		{
+			name:     "no name",
+			document: "profiles:\n- tick_time: 100ms\n",
+		},
+		{
+			name:     "tick time too short",
+			document: "profiles:\n- name: fast\n  tick_time: 1ms\n",
+		},
+		{
+			name:     "zero lookup distance",
+			document: "profiles:\n- name: blind\n  lookup_distance: 0\n",
+		},
+		{
+			name:     "unknown discoverer",
+			document: "profiles:\n- name: lost\n  discoverer: compass\n",
+		},
+		{
+			name:     "negative rollouts",
+			document: "profiles:\n- name: lazy\n  rollouts: -1\n",
+		},
+		{
+			name:     "corrupted document",
+			document: "profiles: [",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles := NewProfiles()
+			err := profiles.Load(strings.NewReader(tt.document))
+			require.Error(t, err)
+			require.Len(t, profiles.Names(), len(builtinProfiles))
+		})
+	}
+}
+
+func TestProfiles_Load_Empty(t *testing.T) {
+	profiles := NewProfiles()
+	require.NoError(t, profiles.Load(strings.NewReader("")))
+	require.Len(t, profiles.Names(), len(builtinProfiles))
+}
@@ -0,0 +1,128 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot/engine"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+const viewerBufferSize = 4
+
+// viewers are the watchers of the views of a bot.
+type viewers struct {
+	mux   sync.Mutex
+	chans map[chan *models.BotView]struct{}
+}
+
+// Watch returns a channel of what the bot sees and decides on every tick.
+// A watcher that falls behind misses views. The channel is closed when
+// the context is done.
+func (b *Bot) Watch(ctx context.Context) <-chan *models.BotView {
+	ch := make(chan *models.BotView, viewerBufferSize)
+
+	b.viewers.mux.Lock()
+	if b.viewers.chans == nil {
+		b.viewers.chans = make(map[chan *models.BotView]struct{})
+	}
+	b.viewers.chans[ch] = struct{}{}
+	b.viewers.mux.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.viewers.mux.Lock()
+		delete(b.viewers.chans, ch)
+		close(ch)
+		b.viewers.mux.Unlock()
+	}()
+
+	return ch
+}
+
+// watched tells whether the bot has to make views.
+func (b *Bot) watched() bool {
+	b.viewers.mux.Lock()
+	defer b.viewers.mux.Unlock()
+
+	return len(b.viewers.chans) > 0
+}
+
+func (b *Bot) publishView(view *models.BotView) {
+	b.viewers.mux.Lock()
+	defer b.viewers.mux.Unlock()
+
+	for ch := range b.viewers.chans {
+		select {
+		case ch <- view:
+		default:
+		}
+	}
+}
+
+func (b *Bot) setRendering(sight engine.Sight, objects,
+	scores *engine.HashmapSight, path []types.Dot) {
+	b.renderingMux.Lock()
+	b.rendering.Sight = sight
+	b.rendering.Objects = objects
+	b.rendering.Scores = scores
+	b.rendering.Path = path
+	b.rendered = true
+	b.renderingMux.Unlock()
+}
+
+var ErrNotRendered = errors.New("bot has not looked around yet")
+
+// RenderPNG draws what the bot has seen and decided on the last tick.
+func (b *Bot) RenderPNG(w io.Writer) error {
+	b.renderingMux.Lock()
+	rendering, rendered := b.rendering, b.rendered
+	b.renderingMux.Unlock()
+
+	if !rendered {
+		return ErrNotRendered
+	}
+	return rendering.PNG(w)
+}
+
+func viewDot(dot types.Dot) models.ViewDot {
+	return models.ViewDot{dot.X, dot.Y}
+}
+
+// makeView describes a decision of the bot.
+func (b *Bot) makeView(head types.Dot, sight engine.Sight,
+	scores *engine.HashmapSight, path []types.Dot,
+	direction types.Direction) *models.BotView {
+
+	width, height := sight.Size()
+	view := &models.BotView{
+		Snake: b.getMe(),
+		Head:  viewDot(head),
+		Sight: models.ViewSight{
+			TopLeft: viewDot(sight.TopLeft()),
+			Width:   width,
+			Height:  height,
+		},
+		Scores:    []models.ScoredDot{},
+		Path:      make([]models.ViewDot, 0, len(path)),
+		Direction: string(direction),
+	}
+
+	scores.ForEach(func(dot types.Dot, v interface{}) {
+		if score, ok := v.(int); ok {
+			view.Scores = append(view.Scores, models.ScoredDot{
+				Dot:   viewDot(dot),
+				Score: score,
+			})
+		}
+	})
+	for _, dot := range path {
+		view.Path = append(view.Path, viewDot(dot))
+	}
+
+	return view
+}
@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+func Test_Bot_Watch(t *testing.T) {
+	game := NewGame()
+	game.Size(30, 30)
+	game.Create(&types.Object{
+		Type: types.ObjectTypeSnake,
+		Id:   1,
+		Dots: []types.Dot{
+			{X: 10, Y: 10},
+			{X: 10, Y: 11},
+			{X: 10, Y: 12},
+		},
+	})
+	game.Create(&types.Object{
+		Type: types.ObjectTypeApple,
+		Id:   2,
+		Dot:  types.Dot{X: 13, Y: 10},
+	})
+
+	profile := defaultProfile
+	b := NewDijkstrasBot(game, &profile, 1)
+	b.Me(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	views := b.Watch(ctx)
+
+	direction, ok := b.Tick(ctx)
+	require.True(t, ok)
+
+	view := <-views
+	require.Equal(t, uint32(1), view.Snake)
+	require.Equal(t, models.ViewDot{10, 10}, view.Head)
+	require.NotZero(t, view.Sight.Width)
+	require.NotZero(t, view.Sight.Height)
+	require.NotEmpty(t, view.Scores)
+	require.NotEmpty(t, view.Path)
+	require.Equal(t, string(direction), view.Direction)
+
+	cancel()
+	_, open := <-views
+	require.False(t, open)
+	require.False(t, b.watched())
+}
+
+func Test_Bot_RenderPNG(t *testing.T) {
+	game := NewGame()
+	game.Size(30, 30)
+	game.Create(&types.Object{
+		Type: types.ObjectTypeSnake,
+		Id:   1,
+		Dots: []types.Dot{
+			{X: 10, Y: 10},
+			{X: 10, Y: 11},
+			{X: 10, Y: 12},
+		},
+	})
+
+	profile := defaultProfile
+	b := NewDijkstrasBot(game, &profile, 1)
+	b.Me(1)
+
+	var buf bytes.Buffer
+	require.ErrorIs(t, b.RenderPNG(&buf), ErrNotRendered)
+
+	b.Tick(context.Background())
+	require.NoError(t, b.RenderPNG(&buf))
+	_, err := png.Decode(&buf)
+	require.NoError(t, err)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"flag"
 	"fmt"
+	"time"
 )
 
 // Default values for the server's settings
@@ -12,15 +13,33 @@ const (
 	defaultForbidCORS = false
 	defaultDebug      = false
 
-	defaultSnakeServer = "localhost:8080"
-	defaultWSS         = false
-
-	defaultBotsLimit = 100
+	defaultSnakeServer      = "localhost:8080"
+	defaultWSS              = false
+	defaultBreakerThreshold = 20
+	defaultBreakerCooldown  = time.Second * 30
+
+	defaultBotsLimit      = 100
+	defaultBotsDiscoverer = "dijkstras"
+	defaultBotsProfiles   = ""
+	defaultBotsSeed       = 0
+	defaultBotsRecord     = ""
+	defaultBotsBackoffMin = time.Second
+	defaultBotsBackoffMax = time.Minute * 2
+	defaultBotsDelayMin   = time.Second * 3
+	defaultBotsDelayMax   = time.Second * 15
+	defaultBotsDrain      = time.Minute
 
 	defaultLogEnableJSON = false
 	defaultLogLevel      = "info"
 
 	defaultStoragePath = ""
+
+	defaultFillOccupancy = 0
+	defaultFillPlayers   = 0
+	defaultFillYield     = false
+	defaultFillMinBots   = 0
+	defaultFillMaxBots   = 0
+	defaultFillInterval  = time.Second * 30
 )
 
 // Flag labels
@@ -30,15 +49,33 @@ const (
 	flagLabelForbidCORS = "forbid-cors"
 	flagLabelDebug      = "debug"
 
-	flagLabelSnakeServer = "snake-server"
-	flagLabelWSS         = "wss"
-
-	flagLabelBotsLimit = "bots-limit"
+	flagLabelSnakeServer      = "snake-server"
+	flagLabelWSS              = "wss"
+	flagLabelBreakerThreshold = "breaker-threshold"
+	flagLabelBreakerCooldown  = "breaker-cooldown"
+
+	flagLabelBotsLimit      = "bots-limit"
+	flagLabelBotsDiscoverer = "bots-discoverer"
+	flagLabelBotsProfiles   = "bots-profiles"
+	flagLabelBotsSeed       = "bots-seed"
+	flagLabelBotsRecord     = "bots-record"
+	flagLabelBotsBackoffMin = "bots-backoff-min"
+	flagLabelBotsBackoffMax = "bots-backoff-max"
+	flagLabelBotsDelayMin   = "bots-delay-min"
+	flagLabelBotsDelayMax   = "bots-delay-max"
+	flagLabelBotsDrain      = "bots-drain"
 
 	flagLabelLogEnableJSON = "log-json"
 	flagLabelLogLevel      = "log-level"
 
 	flagLabelStoragePath = "storage"
+
+	flagLabelFillOccupancy = "fill-occupancy"
+	flagLabelFillPlayers   = "fill-players"
+	flagLabelFillYield     = "fill-yield"
+	flagLabelFillMinBots   = "fill-min-bots"
+	flagLabelFillMaxBots   = "fill-max-bots"
+	flagLabelFillInterval  = "fill-interval"
 )
 
 // Flag usage descriptions
@@ -48,15 +85,33 @@ const (
 	flagUsageForbidCORS = "forbid cross-origin resource sharing"
 	flagUsageDebug      = "add profiling routes"
 
-	flagUsageSnakeServer = "snake server's address: host:port"
-	flagUsageWSS         = "use secure web-socket connection"
-
-	flagUsageBotsLimit = "overall bots limit"
+	flagUsageSnakeServer      = "snake server's address: host:port"
+	flagUsageWSS              = "use secure web-socket connection"
+	flagUsageBreakerThreshold = "failed connections in a row to pause connecting, 0 to never pause"
+	flagUsageBreakerCooldown  = "pause of connecting after the failures"
+
+	flagUsageBotsLimit      = "overall bots limit"
+	flagUsageBotsDiscoverer = "path discovery algorithm: dijkstras, astar, lookahead or mcts"
+	flagUsageBotsProfiles   = "path to a YAML file with bot behavior profiles"
+	flagUsageBotsSeed       = "seed to derive bots' seeds from, 0 for a random seed"
+	flagUsageBotsRecord     = "directory to record bots' sessions to"
+	flagUsageBotsBackoffMin = "minimum delay between connection attempts of a bot"
+	flagUsageBotsBackoffMax = "maximum delay between connection attempts of a bot"
+	flagUsageBotsDelayMin   = "minimum random delay before every session of a bot"
+	flagUsageBotsDelayMax   = "maximum random delay before every session of a bot"
+	flagUsageBotsDrain      = "time bots are given to finish their lives on drain"
 
 	flagUsageLogEnableJSON = "use json logging format"
 	flagUsageLogLevel      = "log level: panic, fatal, error, warning, info or debug"
 
 	flagUsageStoragePath = "path to a state file"
+
+	flagUsageFillOccupancy = "share of the players limit of every game to fill with bots: 0-1"
+	flagUsageFillPlayers   = "number of players to keep in every game with bots"
+	flagUsageFillYield     = "remove a bot from a game for every human that joins"
+	flagUsageFillMinBots   = "minimum number of bots in every game"
+	flagUsageFillMaxBots   = "maximum number of bots in every game, 0 means no limit"
+	flagUsageFillInterval  = "interval between the checks of the games to fill"
 )
 
 // Server structure contains configurations for the server
@@ -70,10 +125,30 @@ type Server struct {
 type Target struct {
 	Address string
 	WSS     bool
+	// BreakerThreshold is the number of the failed connections in a row
+	// after which connecting is paused for BreakerCooldown.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
 }
 
 type Bots struct {
-	Limit int
+	Limit      int
+	Discoverer string
+	Profiles   string
+	Seed       int64
+	Record     string
+	// BackoffMin and BackoffMax bound the delays between the connection
+	// attempts of a bot.
+	BackoffMin time.Duration
+	BackoffMax time.Duration
+	// DelayMin and DelayMax bound the random delay before every session
+	// of a bot.
+	DelayMin time.Duration
+	DelayMax time.Duration
+	// Drain is the time the bots are given to finish the lives of their
+	// snakes when the application is drained. The remaining bots are
+	// stopped after that.
+	Drain time.Duration
 }
 
 // Log structure defines preferences for logging
@@ -87,6 +162,18 @@ type Storage struct {
 	Path string
 }
 
+// Fill structure defines the policy of filling the games of the target
+// server with bots. The policy is disabled if neither the occupancy nor
+// the players nor the minimum of bots are set.
+type Fill struct {
+	Occupancy float64
+	Players   int
+	Yield     bool
+	MinBots   int
+	MaxBots   int
+	Interval  time.Duration
+}
+
 // Config is a base server configuration structure
 type Config struct {
 	Server  Server
@@ -94,6 +181,7 @@ type Config struct {
 	Log     Log
 	Bots    Bots
 	Storage Storage
+	Fill    Fill
 }
 
 // Fields returns a map of all configurations
@@ -104,15 +192,33 @@ func (c Config) Fields() map[string]interface{} {
 		flagLabelForbidCORS: c.Server.ForbidCORS,
 		flagLabelDebug:      c.Server.Debug,
 
-		flagLabelSnakeServer: c.Target.Address,
-		flagLabelWSS:         c.Target.WSS,
-
-		flagLabelBotsLimit: c.Bots.Limit,
+		flagLabelSnakeServer:      c.Target.Address,
+		flagLabelWSS:              c.Target.WSS,
+		flagLabelBreakerThreshold: c.Target.BreakerThreshold,
+		flagLabelBreakerCooldown:  c.Target.BreakerCooldown,
+
+		flagLabelBotsLimit:      c.Bots.Limit,
+		flagLabelBotsDiscoverer: c.Bots.Discoverer,
+		flagLabelBotsProfiles:   c.Bots.Profiles,
+		flagLabelBotsSeed:       c.Bots.Seed,
+		flagLabelBotsRecord:     c.Bots.Record,
+		flagLabelBotsBackoffMin: c.Bots.BackoffMin,
+		flagLabelBotsBackoffMax: c.Bots.BackoffMax,
+		flagLabelBotsDelayMin:   c.Bots.DelayMin,
+		flagLabelBotsDelayMax:   c.Bots.DelayMax,
+		flagLabelBotsDrain:      c.Bots.Drain,
 
 		flagLabelLogEnableJSON: c.Log.EnableJSON,
 		flagLabelLogLevel:      c.Log.Level,
 
 		flagLabelStoragePath: c.Storage.Path,
+
+		flagLabelFillOccupancy: c.Fill.Occupancy,
+		flagLabelFillPlayers:   c.Fill.Players,
+		flagLabelFillYield:     c.Fill.Yield,
+		flagLabelFillMinBots:   c.Fill.MinBots,
+		flagLabelFillMaxBots:   c.Fill.MaxBots,
+		flagLabelFillInterval:  c.Fill.Interval,
 	}
 }
 
@@ -126,12 +232,23 @@ var defaultConfig = Config{
 	},
 
 	Target: Target{
-		Address: defaultSnakeServer,
-		WSS:     defaultWSS,
+		Address:          defaultSnakeServer,
+		WSS:              defaultWSS,
+		BreakerThreshold: defaultBreakerThreshold,
+		BreakerCooldown:  defaultBreakerCooldown,
 	},
 
 	Bots: Bots{
-		Limit: defaultBotsLimit,
+		Limit:      defaultBotsLimit,
+		Discoverer: defaultBotsDiscoverer,
+		Profiles:   defaultBotsProfiles,
+		Seed:       defaultBotsSeed,
+		Record:     defaultBotsRecord,
+		BackoffMin: defaultBotsBackoffMin,
+		BackoffMax: defaultBotsBackoffMax,
+		DelayMin:   defaultBotsDelayMin,
+		DelayMax:   defaultBotsDelayMax,
+		Drain:      defaultBotsDrain,
 	},
 
 	Log: Log{
@@ -142,6 +259,15 @@ var defaultConfig = Config{
 	Storage: Storage{
 		Path: defaultStoragePath,
 	},
+
+	Fill: Fill{
+		Occupancy: defaultFillOccupancy,
+		Players:   defaultFillPlayers,
+		Yield:     defaultFillYield,
+		MinBots:   defaultFillMinBots,
+		MaxBots:   defaultFillMaxBots,
+		Interval:  defaultFillInterval,
+	},
 }
 
 // DefaultConfig returns configuration by default
@@ -171,9 +297,31 @@ func ParseFlags(flagSet *flag.FlagSet, args []string, defaults Config) (Config,
 		defaults.Target.Address, flagUsageSnakeServer)
 	flagSet.BoolVar(&config.Target.WSS, flagLabelWSS,
 		defaults.Target.WSS, flagUsageWSS)
+	flagSet.IntVar(&config.Target.BreakerThreshold, flagLabelBreakerThreshold,
+		defaults.Target.BreakerThreshold, flagUsageBreakerThreshold)
+	flagSet.DurationVar(&config.Target.BreakerCooldown, flagLabelBreakerCooldown,
+		defaults.Target.BreakerCooldown, flagUsageBreakerCooldown)
 
 	flagSet.IntVar(&config.Bots.Limit, flagLabelBotsLimit,
 		defaults.Bots.Limit, flagUsageBotsLimit)
+	flagSet.StringVar(&config.Bots.Discoverer, flagLabelBotsDiscoverer,
+		defaults.Bots.Discoverer, flagUsageBotsDiscoverer)
+	flagSet.StringVar(&config.Bots.Profiles, flagLabelBotsProfiles,
+		defaults.Bots.Profiles, flagUsageBotsProfiles)
+	flagSet.Int64Var(&config.Bots.Seed, flagLabelBotsSeed,
+		defaults.Bots.Seed, flagUsageBotsSeed)
+	flagSet.StringVar(&config.Bots.Record, flagLabelBotsRecord,
+		defaults.Bots.Record, flagUsageBotsRecord)
+	flagSet.DurationVar(&config.Bots.BackoffMin, flagLabelBotsBackoffMin,
+		defaults.Bots.BackoffMin, flagUsageBotsBackoffMin)
+	flagSet.DurationVar(&config.Bots.BackoffMax, flagLabelBotsBackoffMax,
+		defaults.Bots.BackoffMax, flagUsageBotsBackoffMax)
+	flagSet.DurationVar(&config.Bots.DelayMin, flagLabelBotsDelayMin,
+		defaults.Bots.DelayMin, flagUsageBotsDelayMin)
+	flagSet.DurationVar(&config.Bots.DelayMax, flagLabelBotsDelayMax,
+		defaults.Bots.DelayMax, flagUsageBotsDelayMax)
+	flagSet.DurationVar(&config.Bots.Drain, flagLabelBotsDrain,
+		defaults.Bots.Drain, flagUsageBotsDrain)
 
 	// Logging
 	flagSet.BoolVar(&config.Log.EnableJSON, flagLabelLogEnableJSON,
@@ -185,6 +333,20 @@ func ParseFlags(flagSet *flag.FlagSet, args []string, defaults Config) (Config,
 	flagSet.StringVar(&config.Storage.Path, flagLabelStoragePath,
 		defaults.Storage.Path, flagUsageStoragePath)
 
+	// Fill
+	flagSet.Float64Var(&config.Fill.Occupancy, flagLabelFillOccupancy,
+		defaults.Fill.Occupancy, flagUsageFillOccupancy)
+	flagSet.IntVar(&config.Fill.Players, flagLabelFillPlayers,
+		defaults.Fill.Players, flagUsageFillPlayers)
+	flagSet.BoolVar(&config.Fill.Yield, flagLabelFillYield,
+		defaults.Fill.Yield, flagUsageFillYield)
+	flagSet.IntVar(&config.Fill.MinBots, flagLabelFillMinBots,
+		defaults.Fill.MinBots, flagUsageFillMinBots)
+	flagSet.IntVar(&config.Fill.MaxBots, flagLabelFillMaxBots,
+		defaults.Fill.MaxBots, flagUsageFillMaxBots)
+	flagSet.DurationVar(&config.Fill.Interval, flagLabelFillInterval,
+		defaults.Fill.Interval, flagUsageFillInterval)
+
 	if err := flagSet.Parse(args); err != nil {
 		return defaults, fmt.Errorf("cannot parse flags: %s", err)
 	}
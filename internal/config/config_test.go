@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -179,6 +180,155 @@ func Test_ParseFlags_ParsesFlagsCorrectly(t *testing.T) {
 		expectErr:    false,
 	})
 
+	// Test case 10
+	configTest10 := defaultConfig
+	configTest10.Bots.Limit = 30
+	configTest10.Bots.Discoverer = "astar"
+	configTest10.Bots.Profiles = "profiles.yaml"
+
+	tests = append(tests, &Test{
+		msg: "change bots limit, discoverer and profiles",
+
+		args: []string{
+			"-bots-limit", "30",
+			"-bots-discoverer", "astar",
+			"-bots-profiles", "profiles.yaml",
+		},
+		defaults: defaultConfig,
+
+		expectConfig: configTest10,
+		expectErr:    false,
+	})
+
+	// Test case 11
+	configTest11 := defaultConfig
+	configTest11.Bots.Seed = -42
+
+	tests = append(tests, &Test{
+		msg: "change bots seed",
+
+		args: []string{
+			"-bots-seed", "-42",
+		},
+		defaults: defaultConfig,
+
+		expectConfig: configTest11,
+		expectErr:    false,
+	})
+
+	// Test case 12
+	tests = append(tests, &Test{
+		msg: "invalid bots seed",
+
+		args: []string{
+			"-bots-seed", "random",
+		},
+		defaults: defaultConfig,
+
+		expectConfig: defaultConfig,
+		expectErr:    true,
+	})
+
+	// Test case 13
+	configTest13 := defaultConfig
+	configTest13.Bots.Record = "/var/log/snake-bot/sessions"
+
+	tests = append(tests, &Test{
+		msg: "enable recording of sessions",
+
+		args: []string{
+			"-bots-record", "/var/log/snake-bot/sessions",
+		},
+		defaults: defaultConfig,
+
+		expectConfig: configTest13,
+		expectErr:    false,
+	})
+
+	// Test case 14
+	configTest14 := defaultConfig
+	configTest14.Fill.Occupancy = 0.6
+	configTest14.Fill.Players = 3
+	configTest14.Fill.Interval = time.Minute
+
+	tests = append(tests, &Test{
+		msg: "change fill policy",
+
+		args: []string{
+			"-fill-occupancy", "0.6",
+			"-fill-players", "3",
+			"-fill-interval", "1m",
+		},
+		defaults: defaultConfig,
+
+		expectConfig: configTest14,
+		expectErr:    false,
+	})
+
+	// Test case 15
+	configTest15 := defaultConfig
+	configTest15.Fill.Occupancy = 0.8
+	configTest15.Fill.Yield = true
+	configTest15.Fill.MinBots = 1
+	configTest15.Fill.MaxBots = 6
+
+	tests = append(tests, &Test{
+		msg: "change fill bots bounds",
+
+		args: []string{
+			"-fill-occupancy", "0.8",
+			"-fill-yield",
+			"-fill-min-bots", "1",
+			"-fill-max-bots", "6",
+		},
+		defaults: defaultConfig,
+
+		expectConfig: configTest15,
+		expectErr:    false,
+	})
+
+	// Test case 16
+	configTest16 := defaultConfig
+	configTest16.Target.BreakerThreshold = 50
+	configTest16.Target.BreakerCooldown = time.Minute
+	configTest16.Bots.BackoffMin = time.Second * 2
+	configTest16.Bots.BackoffMax = time.Minute * 5
+	configTest16.Bots.DelayMin = time.Second
+	configTest16.Bots.DelayMax = time.Second * 5
+
+	tests = append(tests, &Test{
+		msg: "change reconnection settings",
+
+		args: []string{
+			"-breaker-threshold", "50",
+			"-breaker-cooldown", "1m",
+			"-bots-backoff-min", "2s",
+			"-bots-backoff-max", "5m",
+			"-bots-delay-min", "1s",
+			"-bots-delay-max", "5s",
+		},
+		defaults: defaultConfig,
+
+		expectConfig: configTest16,
+		expectErr:    false,
+	})
+
+	// Test case 17
+	configTest17 := defaultConfig
+	configTest17.Bots.Drain = time.Minute * 3
+
+	tests = append(tests, &Test{
+		msg: "change drain time",
+
+		args: []string{
+			"-bots-drain", "3m",
+		},
+		defaults: defaultConfig,
+
+		expectConfig: configTest17,
+		expectErr:    false,
+	})
+
 	for n, test := range tests {
 		t.Log(test.msg)
 
@@ -204,15 +354,33 @@ func Test_Config_Fields_ReturnsFieldsOfTheConfig(t *testing.T) {
 		flagLabelForbidCORS: true,
 		flagLabelDebug:      true,
 
-		flagLabelSnakeServer: "localhost:9210",
-		flagLabelWSS:         false,
-
-		flagLabelBotsLimit: 1337,
+		flagLabelSnakeServer:      "localhost:9210",
+		flagLabelWSS:              false,
+		flagLabelBreakerThreshold: 5,
+		flagLabelBreakerCooldown:  time.Second * 10,
+
+		flagLabelBotsLimit:      1337,
+		flagLabelBotsDiscoverer: "astar",
+		flagLabelBotsProfiles:   "/etc/snake-bot/profiles.yaml",
+		flagLabelBotsSeed:       int64(7),
+		flagLabelBotsRecord:     "/tmp/sessions",
+		flagLabelBotsBackoffMin: time.Millisecond * 500,
+		flagLabelBotsBackoffMax: time.Minute,
+		flagLabelBotsDelayMin:   time.Second,
+		flagLabelBotsDelayMax:   time.Second * 10,
+		flagLabelBotsDrain:      time.Second * 45,
 
 		flagLabelLogEnableJSON: false,
 		flagLabelLogLevel:      "warning",
 
 		flagLabelStoragePath: "/var/lib/snakepit",
+
+		flagLabelFillOccupancy: 0.5,
+		flagLabelFillPlayers:   4,
+		flagLabelFillYield:     true,
+		flagLabelFillMinBots:   1,
+		flagLabelFillMaxBots:   8,
+		flagLabelFillInterval:  time.Second * 10,
 	}, Config{
 		Server: Server{
 			Address: ":9999",
@@ -222,12 +390,23 @@ func Test_Config_Fields_ReturnsFieldsOfTheConfig(t *testing.T) {
 		},
 
 		Target: Target{
-			Address: "localhost:9210",
-			WSS:     false,
+			Address:          "localhost:9210",
+			WSS:              false,
+			BreakerThreshold: 5,
+			BreakerCooldown:  time.Second * 10,
 		},
 
 		Bots: Bots{
-			Limit: 1337,
+			Limit:      1337,
+			Discoverer: "astar",
+			Profiles:   "/etc/snake-bot/profiles.yaml",
+			Seed:       7,
+			Record:     "/tmp/sessions",
+			BackoffMin: time.Millisecond * 500,
+			BackoffMax: time.Minute,
+			DelayMin:   time.Second,
+			DelayMax:   time.Second * 10,
+			Drain:      time.Second * 45,
 		},
 
 		Log: Log{
@@ -238,5 +417,14 @@ func Test_Config_Fields_ReturnsFieldsOfTheConfig(t *testing.T) {
 		Storage: Storage{
 			Path: "/var/lib/snakepit",
 		},
+
+		Fill: Fill{
+			Occupancy: 0.5,
+			Players:   4,
+			Yield:     true,
+			MinBots:   1,
+			MaxBots:   8,
+			Interval:  time.Second * 10,
+		},
 	}.Fields())
 }
@@ -0,0 +1,154 @@
+package connect
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+// BreakerState is a state of the circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all connections through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen refuses all connections.
+	BreakerOpen
+	// BreakerHalfOpen lets one trial connection through.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned while the breaker refuses connections.
+// It matches ErrCircuitOpen.
+type CircuitOpenError struct {
+	// Cooldown is the rest of the cooldown. It is zero while a trial
+	// connection is in progress.
+	Cooldown time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return ErrCircuitOpen.Error()
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// Breaker pauses connecting when the target server is clearly down. After
+// threshold failed connections in a row the breaker opens and refuses
+// connections for cooldown. Then a single trial connection decides whether
+// the breaker closes or opens again.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	clock     utils.Clock
+
+	mux      sync.Mutex
+	state    BreakerState
+	failures int
+	opened   time.Time
+	trial    bool
+}
+
+func NewBreaker(threshold int, cooldown time.Duration, clock utils.Clock) *Breaker {
+	b := &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     clock,
+	}
+	breakerState.Set(float64(BreakerClosed))
+	return b
+}
+
+// Allow returns a CircuitOpenError if a connection must not be made now.
+// Otherwise the caller has to report the outcome of the connection with
+// Success, Failure or Cancel.
+func (b *Breaker) Allow() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if passed := b.clock.Now().Sub(b.opened); passed < b.cooldown {
+			return &CircuitOpenError{
+				Cooldown: b.cooldown - passed,
+			}
+		}
+		b.setState(BreakerHalfOpen)
+	case BreakerHalfOpen:
+		if b.trial {
+			return &CircuitOpenError{}
+		}
+	default:
+		return nil
+	}
+
+	b.trial = true
+
+	return nil
+}
+
+// Success reports a connection to the server.
+func (b *Breaker) Success() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.failures = 0
+	b.trial = false
+	b.setState(BreakerClosed)
+}
+
+// Failure reports a failed connection.
+func (b *Breaker) Failure() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.failures++
+	b.trial = false
+
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.opened = b.clock.Now()
+		if b.state != BreakerOpen {
+			breakerTrips.Inc()
+		}
+		b.setState(BreakerOpen)
+	}
+}
+
+// Cancel reports a connection that was given up by the caller, so it
+// tells nothing about the server.
+func (b *Breaker) Cancel() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.trial = false
+}
+
+func (b *Breaker) State() BreakerState {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	return b.state
+}
+
+func (b *Breaker) setState(state BreakerState) {
+	b.state = state
+	breakerState.Set(float64(state))
+}
@@ -0,0 +1,116 @@
+package connect_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/config"
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+)
+
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	return make(chan time.Time)
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func Test_Breaker(t *testing.T) {
+	clock := &manualClock{}
+	b := connect.NewBreaker(3, time.Minute, clock)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, b.Allow())
+		b.Failure()
+	}
+	require.Equal(t, connect.BreakerClosed, b.State())
+
+	// A success resets the failures
+	require.NoError(t, b.Allow())
+	b.Success()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, b.Allow())
+		b.Failure()
+	}
+	require.Equal(t, connect.BreakerOpen, b.State())
+	clock.now = clock.now.Add(time.Second * 20)
+	err := b.Allow()
+	require.ErrorIs(t, err, connect.ErrCircuitOpen)
+	require.Equal(t, &connect.CircuitOpenError{
+		Cooldown: time.Second * 40,
+	}, err)
+
+	t.Run("trial fails", func(t *testing.T) {
+		clock.now = clock.now.Add(time.Minute)
+
+		require.NoError(t, b.Allow())
+		require.Equal(t, connect.BreakerHalfOpen, b.State())
+		// Only one trial at a time
+		require.Equal(t, &connect.CircuitOpenError{}, b.Allow())
+
+		b.Failure()
+		require.Equal(t, connect.BreakerOpen, b.State())
+		require.ErrorIs(t, b.Allow(), connect.ErrCircuitOpen)
+	})
+
+	t.Run("trial canceled", func(t *testing.T) {
+		clock.now = clock.now.Add(time.Minute)
+
+		require.NoError(t, b.Allow())
+		b.Cancel()
+		require.Equal(t, connect.BreakerHalfOpen, b.State())
+		require.NoError(t, b.Allow())
+		b.Cancel()
+	})
+
+	t.Run("trial succeeds", func(t *testing.T) {
+		require.NoError(t, b.Allow())
+		b.Success()
+		require.Equal(t, connect.BreakerClosed, b.State())
+		require.NoError(t, b.Allow())
+	})
+}
+
+func Test_Connector_Breaker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	))
+	defer s.Close()
+
+	connector := connect.NewConnector(config.Target{
+		Address:          strings.TrimPrefix(s.URL, "http://"),
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Hour,
+	}, testClientName)
+
+	for i := 0; i < 2; i++ {
+		_, err := connector.Connect(ctx, gameId)
+		require.Error(t, err)
+		require.False(t, errors.Is(err, connect.ErrCircuitOpen))
+	}
+
+	// The server is not dialed anymore
+	_, err := connector.Connect(ctx, gameId)
+	require.True(t, errors.Is(err, connect.ErrCircuitOpen))
+	require.Equal(t, 2, requests)
+}
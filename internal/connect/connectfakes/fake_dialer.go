@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package connectfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+)
+
+type FakeDialer struct {
+	ConnectStub        func(context.Context, int) (connect.Connection, error)
+	connectMutex       sync.RWMutex
+	connectArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	connectReturns struct {
+		result1 connect.Connection
+		result2 error
+	}
+	connectReturnsOnCall map[int]struct {
+		result1 connect.Connection
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDialer) Connect(arg1 context.Context, arg2 int) (connect.Connection, error) {
+	fake.connectMutex.Lock()
+	ret, specificReturn := fake.connectReturnsOnCall[len(fake.connectArgsForCall)]
+	fake.connectArgsForCall = append(fake.connectArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ConnectStub
+	fakeReturns := fake.connectReturns
+	fake.recordInvocation("Connect", []interface{}{arg1, arg2})
+	fake.connectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDialer) ConnectCallCount() int {
+	fake.connectMutex.RLock()
+	defer fake.connectMutex.RUnlock()
+	return len(fake.connectArgsForCall)
+}
+
+func (fake *FakeDialer) ConnectCalls(stub func(context.Context, int) (connect.Connection, error)) {
+	fake.connectMutex.Lock()
+	defer fake.connectMutex.Unlock()
+	fake.ConnectStub = stub
+}
+
+func (fake *FakeDialer) ConnectArgsForCall(i int) (context.Context, int) {
+	fake.connectMutex.RLock()
+	defer fake.connectMutex.RUnlock()
+	argsForCall := fake.connectArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDialer) ConnectReturns(result1 connect.Connection, result2 error) {
+	fake.connectMutex.Lock()
+	defer fake.connectMutex.Unlock()
+	fake.ConnectStub = nil
+	fake.connectReturns = struct {
+		result1 connect.Connection
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDialer) ConnectReturnsOnCall(i int, result1 connect.Connection, result2 error) {
+	fake.connectMutex.Lock()
+	defer fake.connectMutex.Unlock()
+	fake.ConnectStub = nil
+	if fake.connectReturnsOnCall == nil {
+		fake.connectReturnsOnCall = make(map[int]struct {
+			result1 connect.Connection
+			result2 error
+		})
+	}
+	fake.connectReturnsOnCall[i] = struct {
+		result1 connect.Connection
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDialer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.connectMutex.RLock()
+	defer fake.connectMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDialer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ connect.Dialer = new(FakeDialer)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
@@ -101,7 +102,18 @@ func (c *connection) Receive(ctx context.Context) ([]byte, error) {
 	}
 }
 
+// closeFrameTimeout bounds the sending of a close frame.
+const closeFrameTimeout = time.Second
+
 func (c *connection) Close(ctx context.Context) error {
+	// The normal close frame tells the server that the bot leaves on
+	// purpose. It is sent even if the context is canceled as the frame
+	// has its own deadline. The server may have gone already, so
+	// the error is ignored.
+	_ = c.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(closeFrameTimeout))
+
 	// If the context is canceled, close the connection immediately.
 	if errors.Is(ctx.Err(), context.Canceled) {
 		return c.conn.Close()
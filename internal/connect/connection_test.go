@@ -70,6 +70,24 @@ func handlerReceiver(t *testing.T, wg *sync.WaitGroup, expect string) http.Handl
 	})
 }
 
+func handlerCloseWaiter(t *testing.T, wg *sync.WaitGroup) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wg.Add(1)
+		defer wg.Done()
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		_, _, err = c.ReadMessage()
+		require.True(t, websocket.IsCloseError(err,
+			websocket.CloseNormalClosure), err)
+
+		require.NoError(t, c.Close())
+	})
+}
+
 func Test_Connection(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -160,4 +178,26 @@ func Test_Connection(t *testing.T) {
 
 		wg.Wait()
 	})
+
+	t.Run("normal close", func(t *testing.T) {
+		var wg sync.WaitGroup
+		s := httptest.NewServer(handlerCloseWaiter(t, &wg))
+		defer s.Close()
+
+		address := strings.TrimPrefix(s.URL, "http://")
+		conn, err := connect.NewConnector(config.Target{
+			Address: address,
+			WSS:     false,
+		}, testClientName).Connect(ctx, gameId)
+		require.NoError(t, err)
+		require.NotNil(t, conn)
+
+		// The close frame is sent even if the context is canceled.
+		ctx1, cancel := context.WithCancel(ctx)
+		cancel()
+
+		require.NoError(t, conn.Close(ctx1))
+
+		wg.Wait()
+	})
 }
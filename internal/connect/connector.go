@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ivan1993spb/snake-bot/internal/config"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
 )
 
 type Connector struct {
@@ -19,6 +20,9 @@ type Connector struct {
 
 	dialer *websocket.Dialer
 	header http.Header
+
+	// breaker is shared by all bots. It is nil if disabled.
+	breaker *Breaker
 }
 
 const handshakeTimeout = 45 * time.Second
@@ -39,6 +43,11 @@ func NewConnector(cfg config.Target, clientName string) *Connector {
 	c.header = http.Header{}
 	c.header.Add(HeaderClientName, clientName)
 
+	if cfg.BreakerThreshold > 0 {
+		c.breaker = NewBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown,
+			utils.RealClock)
+	}
+
 	return c
 }
 
@@ -63,12 +72,34 @@ func (c *Connector) getGameWebWocketURL(gameId int) *url.URL {
 	return u
 }
 
+// ErrGameNotFound is returned if the target server has no requested game.
+// Unlike other connection errors it is not worth retrying.
+var ErrGameNotFound = errors.New("game not found")
+
 func (c *Connector) Connect(ctx context.Context, gameId int) (Connection, error) {
+	if c.breaker != nil {
+		if err := c.breaker.Allow(); err != nil {
+			return nil, errors.Wrap(err, "failed to connect")
+		}
+	}
+
 	u := c.getGameWebWocketURL(gameId).String()
 	ws, resp, err := c.dialer.DialContext(ctx, u, c.header)
 	if err != nil {
+		// The handshake response tells a deleted game apart from
+		// a temporary failure.
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			c.reportSuccess()
+			return nil, errors.Wrapf(ErrGameNotFound, "game %d", gameId)
+		}
+		if ctx.Err() != nil {
+			c.reportCancel()
+		} else {
+			c.reportFailure()
+		}
 		return nil, errors.Wrap(err, "failed to connect")
 	}
+	c.reportSuccess()
 
 	conn := &connection{
 		conn: ws,
@@ -77,3 +108,21 @@ func (c *Connector) Connect(ctx context.Context, gameId int) (Connection, error)
 
 	return conn, nil
 }
+
+func (c *Connector) reportSuccess() {
+	if c.breaker != nil {
+		c.breaker.Success()
+	}
+}
+
+func (c *Connector) reportFailure() {
+	if c.breaker != nil {
+		c.breaker.Failure()
+	}
+}
+
+func (c *Connector) reportCancel() {
+	if c.breaker != nil {
+		c.breaker.Cancel()
+	}
+}
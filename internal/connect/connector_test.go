@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ivan1993spb/snake-bot/internal/config"
@@ -35,11 +36,34 @@ func Test_Connector_FailedConnection(t *testing.T) {
 
 	t.Run("connect failed", func(t *testing.T) {
 		conn, err := connector.Connect(ctx, gameId)
-		require.Error(t, err)
+		require.True(t, errors.Is(err, connect.ErrGameNotFound))
 		require.Nil(t, conn)
 	})
 }
 
+func Test_Connector_TemporaryFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	))
+	defer s.Close()
+
+	address := strings.TrimPrefix(s.URL, "http://")
+	connector := connect.NewConnector(config.Target{
+		Address: address,
+		WSS:     false,
+	}, testClientName)
+
+	conn, err := connector.Connect(ctx, gameId)
+	require.Error(t, err)
+	require.False(t, errors.Is(err, connect.ErrGameNotFound))
+	require.Nil(t, conn)
+}
+
 func Test_Connector_Success(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -0,0 +1,102 @@
+package connect
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/config"
+)
+
+// Game is a game on the target server.
+type Game struct {
+	Id     int   `json:"id"`
+	Limit  int   `json:"limit"`
+	Count  int   `json:"count"`
+	Width  uint8 `json:"width"`
+	Height uint8 `json:"height"`
+	Rate   int   `json:"rate"`
+}
+
+type gamesResponse struct {
+	Games []*Game `json:"games"`
+}
+
+// GamesClient queries the games API of the target server.
+type GamesClient struct {
+	address string
+	secure  bool
+
+	client *http.Client
+	header http.Header
+}
+
+const gamesRequestTimeout = 10 * time.Second
+
+func NewGamesClient(cfg config.Target, clientName string) *GamesClient {
+	c := &GamesClient{
+		address: cfg.Address,
+		secure:  cfg.WSS,
+		client: &http.Client{
+			Timeout: gamesRequestTimeout,
+		},
+	}
+
+	c.header = http.Header{}
+	c.header.Add(HeaderClientName, clientName)
+
+	return c
+}
+
+const gamesPath = "/api/games"
+
+func (c *GamesClient) getGamesURL() *url.URL {
+	u := &url.URL{
+		Host:   c.address,
+		Scheme: "http",
+		Path:   gamesPath,
+	}
+
+	if c.secure {
+		u.Scheme = "https"
+	}
+
+	return u
+}
+
+var ErrUnexpectedStatus = errors.New("unexpected status")
+
+const errGamesAnnotation = "failed to get games"
+
+// Games returns the games of the target server.
+func (c *GamesClient) Games(ctx context.Context) ([]*Game, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.getGamesURL().String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errGamesAnnotation)
+	}
+	req.Header = c.header.Clone()
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, errGamesAnnotation)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(errors.WithMessagef(ErrUnexpectedStatus,
+			"%d", resp.StatusCode), errGamesAnnotation)
+	}
+
+	var response gamesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, errors.Wrap(err, errGamesAnnotation)
+	}
+
+	return response.Games, nil
+}
@@ -0,0 +1,57 @@
+package connect_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/config"
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/simulator"
+	"github.com/ivan1993spb/snake-bot/internal/snaketest"
+)
+
+func Test_GamesClient_Games(t *testing.T) {
+	server := snaketest.NewServer(snaketest.Config{
+		Games: []int{3, 1},
+		Game: simulator.Config{
+			Width:  40,
+			Height: 30,
+		},
+		Limit: 10,
+	})
+	defer server.Close()
+
+	client := connect.NewGamesClient(config.Target{
+		Address: server.Address(),
+	}, "test")
+
+	games, err := client.Games(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*connect.Game{
+		{Id: 1, Limit: 10, Width: 40, Height: 30},
+		{Id: 3, Limit: 10, Width: 40, Height: 30},
+	}, games)
+}
+
+func Test_GamesClient_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/games", r.URL.Path)
+			require.Equal(t, "test", r.Header.Get(connect.HeaderClientName))
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+	defer server.Close()
+
+	client := connect.NewGamesClient(config.Target{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	}, "test")
+
+	_, err := client.Games(context.Background())
+	require.True(t, errors.Is(err, connect.ErrUnexpectedStatus))
+}
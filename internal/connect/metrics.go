@@ -0,0 +1,22 @@
+package connect
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	breakerState = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "snake_bot",
+		Subsystem: "connector",
+		Name:      "breaker_state",
+		Help:      "State of the circuit breaker: 0 closed, 1 open, 2 half-open.",
+	})
+
+	breakerTrips = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "snake_bot",
+		Subsystem: "connector",
+		Name:      "breaker_trips_total",
+		Help:      "Number of times the circuit breaker has opened.",
+	})
+)
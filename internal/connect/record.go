@@ -0,0 +1,222 @@
+package connect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+// RecordKind tells whether a recorded message was received or sent.
+type RecordKind string
+
+const (
+	RecordKindReceive RecordKind = "receive"
+	RecordKindSend    RecordKind = "send"
+)
+
+// Record is a line of a session recording.
+type Record struct {
+	Time    time.Time       `json:"time"`
+	Kind    RecordKind      `json:"kind"`
+	Message json.RawMessage `json:"message"`
+	// Text is set if the message was not JSON. Such a message is
+	// recorded as a JSON string.
+	Text bool `json:"text,omitempty"`
+}
+
+var _ Connection = (*recordingConnection)(nil)
+
+// recordingConnection writes the messages passing through the underlying
+// connection to the writer as JSON lines.
+type recordingConnection struct {
+	conn  Connection
+	w     io.WriteCloser
+	clock utils.Clock
+
+	mux     sync.Mutex
+	encoder *json.Encoder
+	// stopped is set once recording fails. The session goes on without
+	// being recorded.
+	stopped bool
+}
+
+// NewRecordingConnection wraps the connection to record its messages.
+// The writer is closed with the connection.
+func NewRecordingConnection(conn Connection, w io.WriteCloser,
+	clock utils.Clock) Connection {
+	return &recordingConnection{
+		conn:    conn,
+		w:       w,
+		clock:   clock,
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (c *recordingConnection) Send(ctx context.Context,
+	data interface{}) error {
+	if err := c.conn.Send(ctx, data); err != nil {
+		return err
+	}
+
+	message, err := json.Marshal(data)
+	if err != nil {
+		c.stop(ctx, errors.Wrap(err, "recording sent data"))
+		return nil
+	}
+
+	c.record(ctx, RecordKindSend, message)
+
+	return nil
+}
+
+func (c *recordingConnection) Receive(ctx context.Context) ([]byte, error) {
+	message, err := c.conn.Receive(ctx)
+	if err != nil {
+		return message, err
+	}
+
+	c.record(ctx, RecordKindReceive, message)
+
+	return message, nil
+}
+
+func (c *recordingConnection) Close(ctx context.Context) error {
+	err := c.conn.Close(ctx)
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if closeErr := c.w.Close(); closeErr != nil && err == nil {
+		err = errors.Wrap(closeErr, "closing recording")
+	}
+
+	return err
+}
+
+// record writes the message to the recording. Recording is a debugging
+// aid, so its failures do not break the session: they are logged and
+// the rest of the session is not recorded.
+func (c *recordingConnection) record(ctx context.Context, kind RecordKind,
+	message []byte) {
+	text := !json.Valid(message)
+	if text {
+		// Keep the line valid anyway.
+		message, _ = json.Marshal(string(message))
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.stopped {
+		return
+	}
+
+	err := c.encoder.Encode(&Record{
+		Time:    c.clock.Now(),
+		Kind:    kind,
+		Message: message,
+		Text:    text,
+	})
+	if err != nil {
+		c.unsafeStop(ctx, errors.Wrap(err, "recording message"))
+	}
+}
+
+func (c *recordingConnection) stop(ctx context.Context, err error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.unsafeStop(ctx, err)
+}
+
+func (c *recordingConnection) unsafeStop(ctx context.Context, err error) {
+	if c.stopped {
+		return
+	}
+	c.stopped = true
+
+	utils.GetLogger(ctx).WithError(err).Error(
+		"recording fail, the session is not recorded anymore")
+}
+
+//counterfeiter:generate . Dialer
+type Dialer interface {
+	Connect(ctx context.Context, gameId int) (Connection, error)
+}
+
+// RecordingConnector records every session to a new file in
+// the directory.
+type RecordingConnector struct {
+	dialer Dialer
+	fs     afero.Fs
+	dir    string
+	clock  utils.Clock
+
+	sessions uint64
+}
+
+func NewRecordingConnector(dialer Dialer, fs afero.Fs, dir string,
+	clock utils.Clock) *RecordingConnector {
+	return &RecordingConnector{
+		dialer: dialer,
+		fs:     fs,
+		dir:    dir,
+		clock:  clock,
+	}
+}
+
+const (
+	recordingFileFormat = "game-%d-%s-%d.jsonl"
+	recordingTimeFormat = "20060102T150405"
+	recordingDirPerm    = 0o755
+	recordingFilePerm   = 0o644
+)
+
+// Connect connects to the game. If the recording file cannot be created,
+// the session isn't recorded.
+func (c *RecordingConnector) Connect(ctx context.Context,
+	gameId int) (Connection, error) {
+	conn, err := c.dialer.Connect(ctx, gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := c.create(gameId)
+	if err != nil {
+		utils.GetLogger(ctx).WithError(err).Error("recording fail")
+		return conn, nil
+	}
+
+	utils.GetLogger(ctx).WithField("path", f.Name()).Info("recording session")
+
+	return NewRecordingConnection(conn, f, c.clock), nil
+}
+
+func (c *RecordingConnector) create(gameId int) (afero.File, error) {
+	if err := c.fs.MkdirAll(c.dir, recordingDirPerm); err != nil {
+		return nil, errors.Wrap(err, "creating recordings directory")
+	}
+
+	n := atomic.AddUint64(&c.sessions, 1)
+	name := fmt.Sprintf(recordingFileFormat, gameId,
+		c.clock.Now().UTC().Format(recordingTimeFormat), n)
+
+	f, err := c.fs.OpenFile(filepath.Join(c.dir, name),
+		os.O_CREATE|os.O_EXCL|os.O_WRONLY, recordingFilePerm)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating recording")
+	}
+
+	return f, nil
+}
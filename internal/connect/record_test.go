@@ -0,0 +1,256 @@
+package connect_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/connect/connectfakes"
+	"github.com/ivan1993spb/snake-bot/internal/types"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+type stepClock struct {
+	now time.Time
+}
+
+func (c *stepClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *stepClock) Now() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func Test_RecordingConnection(t *testing.T) {
+	ctx := context.Background()
+
+	fake := &connectfakes.FakeConnection{}
+	fake.ReceiveReturnsOnCall(0, []byte(`{"type":"player"}`), nil)
+	fake.ReceiveReturnsOnCall(1, nil, connect.ErrConnectionClosed)
+
+	buf := &bufferCloser{}
+	conn := connect.NewRecordingConnection(fake, buf, &stepClock{})
+
+	message, err := conn.Receive(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []byte(`{"type":"player"}`), message)
+
+	err = conn.Send(ctx, types.DirectionNorth.ToMessageSnakeCommand())
+	require.Nil(t, err)
+	require.Equal(t, 1, fake.SendCallCount())
+
+	_, err = conn.Receive(ctx)
+	require.Equal(t, connect.ErrConnectionClosed, err)
+
+	require.Nil(t, conn.Close(ctx))
+	require.True(t, buf.closed)
+	require.Equal(t, 1, fake.CloseCallCount())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var record connect.Record
+	require.Nil(t, json.Unmarshal([]byte(lines[0]), &record))
+	require.Equal(t, connect.RecordKindReceive, record.Kind)
+	require.JSONEq(t, `{"type":"player"}`, string(record.Message))
+
+	require.Nil(t, json.Unmarshal([]byte(lines[1]), &record))
+	require.Equal(t, connect.RecordKindSend, record.Kind)
+	require.JSONEq(t, `{"type":"snake","payload":"north"}`,
+		string(record.Message))
+}
+
+func Test_RecordingConnection_SendFailure(t *testing.T) {
+	fake := &connectfakes.FakeConnection{}
+	fake.SendReturns(errors.New("broken pipe"))
+
+	buf := &bufferCloser{}
+	conn := connect.NewRecordingConnection(fake, buf, &stepClock{})
+
+	err := conn.Send(context.Background(),
+		types.DirectionNorth.ToMessageSnakeCommand())
+	require.NotNil(t, err)
+	require.Zero(t, buf.Len())
+}
+
+// failingWriter fails every write.
+type failingWriter struct {
+	writes int
+	closed bool
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("no space left on device")
+}
+
+func (w *failingWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func Test_RecordingConnection_RecordFailure(t *testing.T) {
+	ctx := utils.WithLogger(context.Background(), utils.DiscardEntry)
+
+	fake := &connectfakes.FakeConnection{}
+	fake.ReceiveReturns([]byte(`{"type":"player"}`), nil)
+
+	w := &failingWriter{}
+	conn := connect.NewRecordingConnection(fake, w, &stepClock{})
+
+	// The session goes on without the recording.
+	message, err := conn.Receive(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []byte(`{"type":"player"}`), message)
+
+	err = conn.Send(ctx, types.DirectionNorth.ToMessageSnakeCommand())
+	require.Nil(t, err)
+	require.Equal(t, 1, fake.SendCallCount())
+
+	_, err = conn.Receive(ctx)
+	require.Nil(t, err)
+
+	// Recording stops after the first failure.
+	require.Equal(t, 1, w.writes)
+
+	require.Nil(t, conn.Close(ctx))
+	require.True(t, w.closed)
+}
+
+func Test_RecordingConnector(t *testing.T) {
+	ctx := context.Background()
+	fs := afero.NewMemMapFs()
+
+	dialer := &connectfakes.FakeDialer{}
+	dialer.ConnectReturns(&connectfakes.FakeConnection{}, nil)
+
+	connector := connect.NewRecordingConnector(dialer, fs, "/sessions",
+		&stepClock{})
+
+	conn, err := connector.Connect(ctx, 12)
+	require.Nil(t, err)
+	require.Nil(t, conn.Send(ctx, types.DirectionEast.ToMessageSnakeCommand()))
+	require.Nil(t, conn.Close(ctx))
+
+	_, err = connector.Connect(ctx, 12)
+	require.Nil(t, err)
+
+	files, err := afero.ReadDir(fs, "/sessions")
+	require.Nil(t, err)
+	require.Len(t, files, 2)
+	require.True(t, strings.HasPrefix(files[0].Name(), "game-12-"))
+	require.Positive(t, files[0].Size())
+}
+
+func Test_RecordingConnector_ConnectFailure(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	dialer := &connectfakes.FakeDialer{}
+	dialer.ConnectReturns(nil, errors.New("connection refused"))
+
+	connector := connect.NewRecordingConnector(dialer, fs, "/sessions",
+		&stepClock{})
+
+	_, err := connector.Connect(context.Background(), 1)
+	require.NotNil(t, err)
+
+	exists, err := afero.DirExists(fs, "/sessions")
+	require.Nil(t, err)
+	require.False(t, exists)
+}
+
+func Test_ReplayConnection(t *testing.T) {
+	ctx := context.Background()
+
+	fake := &connectfakes.FakeConnection{}
+	fake.ReceiveReturnsOnCall(0, []byte(`{"type":"game"}`), nil)
+	fake.ReceiveReturnsOnCall(1, []byte(`not json`), nil)
+	fake.ReceiveReturnsOnCall(2, []byte(`"a string"`), nil)
+	fake.ReceiveReturnsOnCall(3, []byte(`{"type":"player"}`), nil)
+
+	buf := &bufferCloser{}
+	recorder := connect.NewRecordingConnection(fake, buf, &stepClock{})
+	_, err := recorder.Receive(ctx)
+	require.Nil(t, err)
+	require.Nil(t, recorder.Send(ctx,
+		types.DirectionWest.ToMessageSnakeCommand()))
+	for i := 0; i < 3; i++ {
+		_, err = recorder.Receive(ctx)
+		require.Nil(t, err)
+	}
+
+	clock := &stepClock{}
+	replay := connect.NewReplayConnection(&buf.Buffer, clock)
+
+	message, err := replay.Receive(ctx)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"type":"game"}`, string(message))
+
+	require.Nil(t, replay.Send(ctx,
+		types.DirectionWest.ToMessageSnakeCommand()))
+
+	before := clock.now
+	message, err = replay.Receive(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []byte(`not json`), message)
+	// The recorded pause between the messages is kept
+	require.Equal(t, time.Second*2, clock.now.Sub(before))
+
+	// A JSON string is fed back as it was received.
+	message, err = replay.Receive(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []byte(`"a string"`), message)
+
+	message, err = replay.Receive(ctx)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"type":"player"}`, string(message))
+
+	_, err = replay.Receive(ctx)
+	require.Equal(t, connect.ErrConnectionClosed, err)
+
+	sent := replay.Sent()
+	require.Len(t, sent, 1)
+	require.JSONEq(t, `{"type":"snake","payload":"west"}`,
+		string(sent[0].Message))
+
+	recorded := replay.Recorded()
+	require.Len(t, recorded, 1)
+	require.JSONEq(t, `{"type":"snake","payload":"west"}`,
+		string(recorded[0].Message))
+
+	require.Nil(t, replay.Close(ctx))
+}
+
+func Test_ReplayConnection_Broken(t *testing.T) {
+	replay := connect.NewReplayConnection(strings.NewReader("{broken"),
+		utils.ImmediatelyClock)
+
+	_, err := replay.Receive(context.Background())
+	require.NotNil(t, err)
+	require.NotEqual(t, connect.ErrConnectionClosed, err)
+	require.False(t, errors.Is(err, io.EOF))
+}
@@ -0,0 +1,126 @@
+package connect
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+var _ Connection = (*ReplayConnection)(nil)
+
+// ReplayConnection feeds the received messages of a session recording
+// back to a bot. The pauses between the messages are kept, so a bot
+// makes its decisions at the same moments of the game as it did when
+// the session was recorded. The messages sent by the bot are collected
+// to be compared with the recorded ones.
+type ReplayConnection struct {
+	decoder *json.Decoder
+	clock   utils.Clock
+
+	rmux     sync.Mutex
+	last     time.Time
+	recorded []Record
+
+	wmux sync.Mutex
+	sent []Record
+}
+
+// NewReplayConnection creates a connection replaying the recording.
+// With utils.ImmediatelyClock the messages are fed without pauses.
+func NewReplayConnection(r io.Reader, clock utils.Clock) *ReplayConnection {
+	return &ReplayConnection{
+		decoder: json.NewDecoder(r),
+		clock:   clock,
+	}
+}
+
+func (c *ReplayConnection) Send(ctx context.Context, data interface{}) error {
+	message, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "sending data")
+	}
+
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+
+	c.sent = append(c.sent, Record{
+		Time:    c.clock.Now(),
+		Kind:    RecordKindSend,
+		Message: message,
+	})
+
+	return nil
+}
+
+// Receive returns the next received message of the recording as it was
+// received. When the recording is over, it returns ErrConnectionClosed.
+func (c *ReplayConnection) Receive(ctx context.Context) ([]byte, error) {
+	c.rmux.Lock()
+	defer c.rmux.Unlock()
+
+	for {
+		var record Record
+		if err := c.decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, ErrConnectionClosed
+			}
+			return nil, errors.Wrap(err, "reading recording")
+		}
+
+		if record.Kind == RecordKindSend {
+			c.recorded = append(c.recorded, record)
+			continue
+		}
+		if record.Kind != RecordKindReceive {
+			continue
+		}
+
+		if !c.last.IsZero() {
+			if pause := record.Time.Sub(c.last); pause > 0 {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-c.clock.After(pause):
+				}
+			}
+		}
+		c.last = record.Time
+
+		if record.Text {
+			var message string
+			if err := json.Unmarshal(record.Message, &message); err != nil {
+				return nil, errors.Wrap(err, "reading recording")
+			}
+			return []byte(message), nil
+		}
+
+		return record.Message, nil
+	}
+}
+
+func (c *ReplayConnection) Close(ctx context.Context) error {
+	return nil
+}
+
+// Recorded returns the messages the recorded bot sent, as far as the
+// recording has been replayed.
+func (c *ReplayConnection) Recorded() []Record {
+	c.rmux.Lock()
+	defer c.rmux.Unlock()
+
+	return append([]Record(nil), c.recorded...)
+}
+
+// Sent returns the messages sent by the bot.
+func (c *ReplayConnection) Sent() []Record {
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+
+	return append([]Record(nil), c.sent...)
+}
@@ -0,0 +1,72 @@
+package core
+
+import "time"
+
+const (
+	defaultBackoffMin = time.Second
+	defaultBackoffMax = time.Minute * 2
+
+	defaultBackoffStartMin = time.Second * 3
+	defaultBackoffStartMax = time.Second * 15
+)
+
+// Backoff defines the delays between the connection attempts of a bot.
+// The delay doubles with every failed attempt from Min up to Max. A random
+// part of up to a half of the delay is cut off, so the bots reconnecting
+// at once spread out.
+//
+// StartMin and StartMax bound the random delay before every session of
+// a bot, so the bots started at once don't connect at once.
+type Backoff struct {
+	Min time.Duration
+	Max time.Duration
+
+	StartMin time.Duration
+	StartMax time.Duration
+}
+
+// Start returns the delay before a session.
+func (b Backoff) Start(rand Rand) time.Duration {
+	min, max := b.StartMin, b.StartMax
+	if min <= 0 {
+		min = defaultBackoffStartMin
+	}
+	if max <= 0 {
+		max = defaultBackoffStartMax
+	}
+	if max <= min {
+		return min
+	}
+
+	return min + time.Duration(rand.Intn(int(max-min)))
+}
+
+// Delay returns the delay before the attempt following the given number
+// of the failed attempts.
+func (b Backoff) Delay(failures int, rand Rand) time.Duration {
+	min, max := b.Min, b.Max
+	if min <= 0 {
+		min = defaultBackoffMin
+	}
+	if max <= 0 {
+		max = defaultBackoffMax
+	}
+	if max < min {
+		max = min
+	}
+
+	delay := min
+	for i := 0; i < failures && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+
+	jitter := delay / 2
+	if jitter <= 0 {
+		return delay
+	}
+
+	return delay - time.Duration(rand.Intn(int(jitter)))
+}
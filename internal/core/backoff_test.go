@@ -0,0 +1,61 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/core/corefakes"
+)
+
+func Test_Backoff_Delay(t *testing.T) {
+	backoff := core.Backoff{
+		Min: time.Second,
+		Max: time.Second * 10,
+	}
+	rand := &corefakes.FakeRand{}
+
+	require.Equal(t, time.Second, backoff.Delay(0, rand))
+	require.Equal(t, time.Second*2, backoff.Delay(1, rand))
+	require.Equal(t, time.Second*8, backoff.Delay(3, rand))
+	require.Equal(t, time.Second*10, backoff.Delay(4, rand))
+	require.Equal(t, time.Second*10, backoff.Delay(100, rand))
+
+	// The jitter cuts off up to a half of the delay
+	rand.IntnStub = func(n int) int {
+		return n - 1
+	}
+	require.Equal(t, time.Second*4+1, backoff.Delay(3, rand))
+	require.Equal(t, int(time.Second*4),
+		rand.IntnArgsForCall(rand.IntnCallCount()-1))
+}
+
+func Test_Backoff_Defaults(t *testing.T) {
+	rand := &corefakes.FakeRand{}
+
+	require.Equal(t, time.Second, core.Backoff{}.Delay(0, rand))
+	require.Equal(t, time.Minute*2, core.Backoff{}.Delay(100, rand))
+}
+
+func Test_Backoff_Start(t *testing.T) {
+	backoff := core.Backoff{
+		StartMin: time.Second,
+		StartMax: time.Second * 5,
+	}
+	rand := &corefakes.FakeRand{}
+	rand.IntnStub = func(n int) int {
+		return n - 1
+	}
+
+	require.Equal(t, time.Second*5-1, backoff.Start(rand))
+	require.Equal(t, int(time.Second*4), rand.IntnArgsForCall(0))
+
+	rand = &corefakes.FakeRand{}
+	require.Equal(t, time.Second*3, core.Backoff{}.Start(rand))
+	require.Equal(t, time.Second*2, core.Backoff{
+		StartMin: time.Second * 2,
+		StartMax: time.Second,
+	}.Start(rand))
+}
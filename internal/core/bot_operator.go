@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"errors"
+	"io"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/ivan1993spb/snake-bot/internal/bot"
 	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 	"github.com/ivan1993spb/snake-bot/internal/parser"
 	"github.com/ivan1993spb/snake-bot/internal/types"
 	"github.com/ivan1993spb/snake-bot/internal/utils"
@@ -19,6 +21,15 @@ import (
 //counterfeiter:generate . BotEngine
 type BotEngine interface {
 	Run(ctx context.Context) <-chan types.Direction
+	// Snake returns the id of the bot's snake, the length of the snake
+	// and whether the snake is alive.
+	Snake() (id uint32, length int, alive bool)
+	// Watch returns a channel of what the bot sees and decides on
+	// every tick until the context is done.
+	Watch(ctx context.Context) <-chan *models.BotView
+	// RenderPNG draws what the bot has seen and decided on the last
+	// tick.
+	RenderPNG(w io.Writer) error
 }
 
 //counterfeiter:generate . Connector
@@ -39,79 +50,224 @@ type Parser interface {
 var _ BotOperator = (*botOperator)(nil)
 
 type botOperator struct {
+	id     int
 	gameId int
+	seed   int64
 
 	connector Connector
 	bot       BotEngine
 	parser    Parser
 	rand      Rand
 	clock     utils.Clock
+	backoff   Backoff
+	metrics   *botMetrics
+	events    *botEvents
 
 	stop chan struct{}
 	once sync.Once
+
+	drain     chan struct{}
+	drainOnce sync.Once
+
+	statusMux sync.Mutex
+	status    string
+	started   time.Time
+	attempts  int
+	lastError string
+	sessions  []*models.BotSession
+	lastSnake uint32
 }
 
 type BotOperatorParams struct {
+	// Id identifies the bot among the bots of the service.
+	Id        int
 	GameId    int
+	Seed      int64
 	Connector Connector
 	BotEngine BotEngine
 	Parser    Parser
 	Rand      Rand
 	Clock     utils.Clock
+	// Backoff is optional. The default one is used if it is not set.
+	Backoff Backoff
+	// Events is optional. It receives the events of the bot.
+	Events Publisher
+
+	// metrics are shared with the parser of the bot. New ones are made
+	// if they are not set.
+	metrics *botMetrics
 }
 
-type DijkstrasBotOperatorFactory struct {
+type DefaultBotOperatorFactory struct {
 	Logger    *logrus.Entry
-	Rand      Rand
 	Connector Connector
 	Clock     utils.Clock
+	Profiles  *bot.Profiles
+	Backoff   Backoff
+	// Events is optional. It receives the events of the bots.
+	Events Publisher
+
+	// Discoverer is the name of the path discovery algorithm
+	// the bots are driven by.
+	Discoverer string
+
+	// Seed is optional. If it is set, the seeds of the bots are
+	// derived from it, so the bots of a game started in the same
+	// order get the same seeds.
+	Seed int64
+
+	mux     sync.Mutex
+	spawned map[int]int64
+	lastId  int
+}
+
+// nextId returns the id for the next bot.
+func (f *DefaultBotOperatorFactory) nextId() int {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	f.lastId++
+	return f.lastId
 }
 
-func (f *DijkstrasBotOperatorFactory) New(gameId int) BotOperator {
+// nextSeed returns the seed for the next bot in the game.
+func (f *DefaultBotOperatorFactory) nextSeed(gameId int) int64 {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.spawned == nil {
+		f.spawned = make(map[int]int64)
+	}
+	n := f.spawned[gameId]
+	f.spawned[gameId]++
+
+	seed := f.Seed
+	if seed == 0 {
+		seed = f.Clock.Now().UnixNano()
+	}
+
+	return utils.DeriveSeed(seed, int64(gameId), n)
+}
+
+func (f *DefaultBotOperatorFactory) New(gameId int, profileName,
+	discoverer string) BotOperator {
+	profile, ok := f.Profiles.Get(profileName)
+	if !ok {
+		f.Logger.WithField("profile", profileName).Error(
+			"falling back to the default profile")
+		profile, _ = f.Profiles.Get(bot.DefaultProfileName)
+	}
+
+	// The discoverer requested for the game goes first, then the one of
+	// the profile and the configured one.
+	if discoverer == "" {
+		discoverer = profile.Discoverer
+	}
+	if discoverer == "" {
+		discoverer = f.Discoverer
+	}
+
+	seed := f.nextSeed(gameId)
+
 	g := bot.NewGame()
-	b := bot.NewDijkstrasBot(g)
+	b, err := bot.NewBotWithDiscoverer(g, discoverer, profile, seed)
+	if err != nil {
+		f.Logger.WithError(err).WithField("discoverer", discoverer).Error(
+			"falling back to the dijkstras discoverer")
+		b = bot.NewDijkstrasBot(g, profile, seed)
+	}
+
+	id := f.nextId()
+	metrics := newBotMetrics(gameId)
+	b.ObserveDiscovery(metrics.discovery)
+	watcher := &botWatcher{
+		bot:     b,
+		metrics: metrics,
+		events: &botEvents{
+			publisher: f.Events,
+			clock:     f.Clock,
+			game:      gameId,
+			bot:       id,
+		},
+	}
+
 	p := &parser.Parser{
-		Countdown: b,
-		Me:        b,
+		Countdown: watcher,
+		Me:        watcher,
 		Size:      g,
 		Game:      g,
 		Printer:   utils.NewPrinterLogger(f.Logger.WithField("game", gameId)),
+		Observer:  metrics,
 	}
 
 	return NewBotOperator(&BotOperatorParams{
+		Id:        id,
 		GameId:    gameId,
+		Seed:      seed,
 		Connector: f.Connector,
 		BotEngine: b,
 		Parser:    p,
-		Rand:      f.Rand,
-		Clock:     f.Clock,
+		// Every bot has its own random delays, so they do not depend
+		// on the order the bots draw them in.
+		Rand:    utils.NewRandSeed(seed),
+		Clock:   f.Clock,
+		Backoff: f.Backoff,
+		Events:  f.Events,
+		metrics: metrics,
 	})
 }
 
 func NewBotOperator(params *BotOperatorParams) BotOperator {
+	metrics := params.metrics
+	if metrics == nil {
+		metrics = newBotMetrics(params.GameId)
+	}
+
 	return &botOperator{
+		id:     params.Id,
 		gameId: params.GameId,
+		seed:   params.Seed,
 
 		connector: params.Connector,
 		bot:       params.BotEngine,
 		parser:    params.Parser,
 		rand:      params.Rand,
 		clock:     params.Clock,
+		backoff:   params.Backoff,
+		metrics:   metrics,
+		events: &botEvents{
+			publisher: params.Events,
+			clock:     params.Clock,
+			game:      params.GameId,
+			bot:       params.Id,
+		},
 
 		stop: make(chan struct{}),
 		once: sync.Once{},
+
+		drain: make(chan struct{}),
+
+		status: models.BotStatusWaiting,
 	}
 }
 
-func (bo *botOperator) Run(ctx context.Context) {
+func (bo *botOperator) Run(ctx context.Context) error {
 	// A context for the whole bot operator.
 	ctx = utils.WithModule(ctx, "operator")
 	ctx = utils.WithTaskId(ctx)
+	ctx = utils.WithField(ctx, "seed", bo.seed)
 	ctx, cancel := context.WithCancel(ctx)
 
 	log := utils.GetLogger(ctx)
 
-	var wg sync.WaitGroup
+	bo.events.publish(&models.Event{
+		Type: models.EventSpawned,
+	})
+
+	var (
+		wg         sync.WaitGroup
+		sessionErr error
+	)
 	wg.Add(2)
 
 	go func() {
@@ -120,6 +276,10 @@ func (bo *botOperator) Run(ctx context.Context) {
 		case <-bo.stop:
 			// Stopping the current bot operator.
 			cancel()
+		case <-bo.drain:
+			// Let the snake finish its life before stopping.
+			bo.waitDeath(ctx)
+			cancel()
 		case <-ctx.Done():
 			// When the whole applictaion is shutting down.
 			return
@@ -132,9 +292,11 @@ func (bo *botOperator) Run(ctx context.Context) {
 		log.Info("bot operator started")
 		defer log.Info("bot operator stopped")
 
-		err := bo.runSession(ctx)
-		if err != nil {
-			log.WithError(err).Error("session failure")
+		sessionErr = bo.runSession(ctx)
+		if sessionErr != nil {
+			log.WithError(sessionErr).Error("session failure")
+			// Release the stop watcher.
+			cancel()
 		}
 	}()
 
@@ -142,28 +304,72 @@ func (bo *botOperator) Run(ctx context.Context) {
 
 	// Call the stop function anyway.
 	bo.Stop()
-}
+	bo.setStatus(models.BotStatusStopped)
+
+	stopped := &models.Event{
+		Type: models.EventStopped,
+	}
+	if sessionErr != nil {
+		stopped.Error = sessionErr.Error()
+	}
+	bo.events.publish(stopped)
 
-const botConnectRetryTimeout = time.Second * 15
+	return sessionErr
+}
 
-// tryConnect tries to connect to the server. It retries until the context is
-// done.
+// tryConnect tries to connect to the server. It retries with growing delays
+// until the context is done or the server reports that the game does not
+// exist. While the circuit breaker is open the bot waits for the cooldown
+// to pass, which does not count as a failure.
 func (bo *botOperator) tryConnect(ctx context.Context) (connect.Connection, error) {
 	log := utils.GetLogger(ctx)
 
+	failures := 0
 	for {
+		bo.connecting()
+
 		conn, err := bo.connector.Connect(ctx, bo.gameId)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
 				return nil, err
 			}
 
-			log.WithError(err).Error("connecting failure")
+			var delay time.Duration
+
+			var open *connect.CircuitOpenError
+			if errors.As(err, &open) {
+				delay = open.Cooldown
+				if delay <= 0 {
+					// A trial connection is in progress.
+					delay = bo.backoff.Delay(0, bo.rand)
+				}
+
+				bo.setStatus(models.BotStatusBackingOff)
+				log.WithField("delay", delay).Info(
+					"circuit breaker is open, waiting")
+			} else {
+				bo.setError(err)
+				bo.metrics.connectionFailure()
+				if errors.Is(err, connect.ErrGameNotFound) {
+					return nil, err
+				}
+
+				bo.setStatus(models.BotStatusBackingOff)
+				bo.events.publish(&models.Event{
+					Type:  models.EventReconnecting,
+					Error: err.Error(),
+				})
+
+				delay = bo.backoff.Delay(failures, bo.rand)
+				failures++
+				log.WithError(err).WithField("delay", delay).Error(
+					"connecting failure")
+			}
 
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-bo.clock.After(botConnectRetryTimeout):
+			case <-bo.clock.After(delay):
 			}
 
 			log.Info("retrying connection")
@@ -171,25 +377,19 @@ func (bo *botOperator) tryConnect(ctx context.Context) (connect.Connection, erro
 		}
 
 		log.Info("connected")
+		bo.connected()
 
 		return conn, nil
 	}
 }
 
-const (
-	botMinConnectionDelayMs = 3000
-	botMaxConnectionDelayMs = 15000
-)
-
 func (bo *botOperator) randomDelay(ctx context.Context) error {
 	log := utils.GetLogger(ctx)
 
-	ms := bo.rand.Intn(botMaxConnectionDelayMs - botMinConnectionDelayMs)
-	ms += botMinConnectionDelayMs
-
-	delay := time.Duration(ms) * time.Millisecond
+	delay := bo.backoff.Start(bo.rand)
 
 	log.WithField("delay", delay).Info("delaying connection")
+	bo.setStatus(models.BotStatusWaiting)
 
 	select {
 	case <-ctx.Done():
@@ -228,6 +428,7 @@ func (bo *botOperator) runSession(ctx context.Context) error {
 		}
 
 		botErr := bo.runBot(ctx, conn)
+		bo.endSession(botErr)
 
 		log.Info("closing connection")
 		if err := conn.Close(ctx); err != nil {
@@ -240,6 +441,10 @@ func (bo *botOperator) runSession(ctx context.Context) error {
 		}
 
 		log.Info("restarting bot")
+		bo.events.publish(&models.Event{
+			Type:  models.EventReconnecting,
+			Error: botErr.Error(),
+		})
 	}
 }
 
@@ -269,6 +474,7 @@ func (bo *botOperator) runBot(ctx context.Context, conn connect.Connection) erro
 
 				return err
 			}
+			bo.metrics.command(direction)
 		}
 
 		return nil
@@ -320,3 +526,158 @@ func (bo *botOperator) Stop() {
 		close(bo.stop)
 	})
 }
+
+func (bo *botOperator) Drain() {
+	bo.drainOnce.Do(func() {
+		close(bo.drain)
+	})
+}
+
+// drainCheckInterval is the interval between the checks whether
+// the snake of a draining bot is still alive.
+const drainCheckInterval = time.Millisecond * 200
+
+// waitDeath waits until the bot has no snake in the game.
+func (bo *botOperator) waitDeath(ctx context.Context) {
+	log := utils.GetLogger(ctx)
+	log.Info("draining bot")
+
+	for bo.Status().Status == models.BotStatusPlaying {
+		select {
+		case <-ctx.Done():
+			return
+		case <-bo.stop:
+			return
+		case <-bo.clock.After(drainCheckInterval):
+		}
+	}
+
+	log.Info("bot drained")
+}
+
+func (bo *botOperator) Seed() int64 {
+	return bo.seed
+}
+
+func (bo *botOperator) Id() int {
+	return bo.id
+}
+
+// Watch streams the bot views until ctx is done or the operator stops,
+// which covers restarts and removals of the game.
+func (bo *botOperator) Watch(ctx context.Context) <-chan *models.BotView {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer cancel()
+		select {
+		case <-ctx.Done():
+		case <-bo.stop:
+		}
+	}()
+	return bo.bot.Watch(ctx)
+}
+
+func (bo *botOperator) RenderPNG(w io.Writer) error {
+	return bo.bot.RenderPNG(w)
+}
+
+// maxBotSessions is the number of the recent sessions kept in the status.
+const maxBotSessions = 10
+
+func (bo *botOperator) setStatus(status string) {
+	bo.statusMux.Lock()
+	defer bo.statusMux.Unlock()
+
+	bo.status = status
+}
+
+func (bo *botOperator) setError(err error) {
+	bo.statusMux.Lock()
+	defer bo.statusMux.Unlock()
+
+	bo.lastError = err.Error()
+}
+
+func (bo *botOperator) connecting() {
+	bo.statusMux.Lock()
+	defer bo.statusMux.Unlock()
+
+	bo.status = models.BotStatusConnecting
+	bo.attempts++
+	bo.metrics.connectionAttempt()
+	bo.events.publish(&models.Event{
+		Type: models.EventConnecting,
+	})
+}
+
+func (bo *botOperator) connected() {
+	// The bot keeps the snake of the previous session until it gets
+	// a new one.
+	snake, _, _ := bo.bot.Snake()
+
+	bo.statusMux.Lock()
+	defer bo.statusMux.Unlock()
+
+	bo.status = models.BotStatusConnected
+	bo.started = bo.clock.Now()
+	bo.lastSnake = snake
+	bo.events.publish(&models.Event{
+		Type: models.EventConnected,
+	})
+}
+
+func (bo *botOperator) endSession(err error) {
+	bo.statusMux.Lock()
+	defer bo.statusMux.Unlock()
+
+	session := &models.BotSession{
+		Started: bo.started,
+		Ended:   bo.clock.Now(),
+	}
+	bo.metrics.session(session.Ended.Sub(session.Started))
+	if err != nil && !errors.Is(err, context.Canceled) {
+		session.Error = err.Error()
+		bo.lastError = session.Error
+	}
+
+	bo.sessions = append(bo.sessions, session)
+	if len(bo.sessions) > maxBotSessions {
+		bo.sessions = bo.sessions[len(bo.sessions)-maxBotSessions:]
+	}
+	bo.started = time.Time{}
+}
+
+func (bo *botOperator) Status() *models.BotStatus {
+	bo.statusMux.Lock()
+	defer bo.statusMux.Unlock()
+
+	status := &models.BotStatus{
+		Id:        bo.id,
+		Status:    bo.status,
+		Seed:      bo.seed,
+		Started:   bo.started,
+		LastError: bo.lastError,
+	}
+	if bo.attempts > 1 {
+		status.Reconnects = bo.attempts - 1
+	}
+	for _, session := range bo.sessions {
+		copied := *session
+		status.Sessions = append(status.Sessions, &copied)
+	}
+
+	if bo.status == models.BotStatusConnected {
+		snake, length, alive := bo.bot.Snake()
+		if snake != bo.lastSnake {
+			status.Snake = snake
+			status.Length = length
+			if alive {
+				status.Status = models.BotStatusPlaying
+			} else {
+				status.Status = models.BotStatusDead
+			}
+		}
+	}
+
+	return status
+}
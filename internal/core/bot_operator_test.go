@@ -2,15 +2,20 @@ package core_test
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
 
+	"github.com/ivan1993spb/snake-bot/internal/bot"
 	"github.com/ivan1993spb/snake-bot/internal/connect"
 	"github.com/ivan1993spb/snake-bot/internal/connect/connectfakes"
 	"github.com/ivan1993spb/snake-bot/internal/core"
 	"github.com/ivan1993spb/snake-bot/internal/core/corefakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 	"github.com/ivan1993spb/snake-bot/internal/types"
 	"github.com/ivan1993spb/snake-bot/internal/utils"
 )
@@ -467,3 +472,343 @@ func Test_BotOperator(t *testing.T) {
 		})
 	})
 }
+
+func Test_DefaultBotOperatorFactory_Seeds(t *testing.T) {
+	newFactory := func(seed int64) *core.DefaultBotOperatorFactory {
+		return &core.DefaultBotOperatorFactory{
+			Logger:     logrus.NewEntry(logrus.New()),
+			Clock:      utils.NeverClock,
+			Profiles:   bot.NewProfiles(),
+			Discoverer: bot.DiscovererDijkstras,
+			Seed:       seed,
+		}
+	}
+
+	seeds := func(factory *core.DefaultBotOperatorFactory) []int64 {
+		return []int64{
+			factory.New(1, "", "").Seed(),
+			factory.New(1, "", "").Seed(),
+			factory.New(2, "", "").Seed(),
+		}
+	}
+
+	first := seeds(newFactory(42))
+	require.Equal(t, first, seeds(newFactory(42)))
+	require.NotEqual(t, first[0], first[1])
+	require.NotEqual(t, first[0], first[2])
+	require.NotEqual(t, first, seeds(newFactory(43)))
+}
+
+func Test_BotOperator_GameNotFound(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testBotOperatorTimeout)
+	defer cancel()
+
+	ctx = utils.WithLogger(ctx, utils.DiscardEntry)
+
+	connector := &corefakes.FakeConnector{}
+	connector.ConnectReturns(nil, errors.Wrap(connect.ErrGameNotFound, "game 99"))
+
+	bus := core.NewBus()
+	events := bus.Subscribe(ctx)
+
+	botOperator := core.NewBotOperator(&core.BotOperatorParams{
+		Id:        3,
+		Events:    bus,
+		GameId:    99,
+		Connector: connector,
+		BotEngine: &corefakes.FakeBotEngine{},
+		Parser:    &corefakes.FakeParser{},
+		Rand:      &corefakes.FakeRand{},
+		Clock:     utils.ImmediatelyClock,
+	})
+
+	err := botOperator.Run(ctx)
+	require.True(t, errors.Is(err, connect.ErrGameNotFound))
+	// The game is not retried
+	require.Equal(t, 1, connector.ConnectCallCount())
+
+	for _, expected := range []string{
+		models.EventSpawned,
+		models.EventConnecting,
+		models.EventStopped,
+	} {
+		event := <-events
+		require.Equal(t, expected, event.Type)
+		require.Equal(t, 99, event.Game)
+		require.Equal(t, 3, event.Bot)
+	}
+}
+
+func Test_BotOperator_Status(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = utils.WithLogger(ctx, utils.DiscardEntry)
+
+	connection := &connectfakes.FakeConnection{
+		ReceiveStub: func(ctx context.Context) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	connector := &corefakes.FakeConnector{}
+	connector.ConnectReturnsOnCall(0, nil, errors.New("connection refused"))
+	connector.ConnectReturnsOnCall(1, connection, nil)
+
+	botEngine := &corefakes.FakeBotEngine{
+		RunStub: func(ctx context.Context) <-chan types.Direction {
+			ch := make(chan types.Direction)
+			go func() {
+				<-ctx.Done()
+				close(ch)
+			}()
+			return ch
+		},
+	}
+	// No snake until the bot gets connected
+	botEngine.SnakeReturnsOnCall(0, 0, 0, false)
+	botEngine.SnakeReturns(5, 3, true)
+
+	botOperator := core.NewBotOperator(&core.BotOperatorParams{
+		GameId:    99,
+		Seed:      7,
+		Connector: connector,
+		BotEngine: botEngine,
+		Parser:    &corefakes.FakeParser{},
+		Rand:      &corefakes.FakeRand{},
+		Clock:     utils.ImmediatelyClock,
+	})
+	require.Equal(t, models.BotStatusWaiting, botOperator.Status().Status)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		botOperator.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return botOperator.Status().Status == models.BotStatusPlaying
+	}, time.Second, time.Millisecond*10)
+
+	status := botOperator.Status()
+	require.Equal(t, int64(7), status.Seed)
+	require.Equal(t, uint32(5), status.Snake)
+	require.Equal(t, 3, status.Length)
+	require.Equal(t, 1, status.Reconnects)
+	require.Equal(t, "connection refused", status.LastError)
+	require.False(t, status.Started.IsZero())
+
+	t.Run("dead", func(t *testing.T) {
+		botEngine.SnakeReturns(5, 0, false)
+		require.Equal(t, models.BotStatusDead, botOperator.Status().Status)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		cancel()
+		<-done
+
+		status := botOperator.Status()
+		require.Equal(t, models.BotStatusStopped, status.Status)
+		require.Len(t, status.Sessions, 1)
+		require.Empty(t, status.Sessions[0].Error)
+	})
+}
+
+func Test_BotOperator_Drain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = utils.WithLogger(ctx, utils.DiscardEntry)
+
+	connection := &connectfakes.FakeConnection{
+		ReceiveStub: func(ctx context.Context) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	connector := &corefakes.FakeConnector{}
+	connector.ConnectReturns(connection, nil)
+
+	botEngine := &corefakes.FakeBotEngine{
+		RunStub: func(ctx context.Context) <-chan types.Direction {
+			ch := make(chan types.Direction)
+			go func() {
+				<-ctx.Done()
+				close(ch)
+			}()
+			return ch
+		},
+	}
+	botEngine.SnakeReturnsOnCall(0, 0, 0, false)
+	botEngine.SnakeReturns(5, 3, true)
+
+	botOperator := core.NewBotOperator(&core.BotOperatorParams{
+		GameId:    99,
+		Connector: connector,
+		BotEngine: botEngine,
+		Parser:    &corefakes.FakeParser{},
+		Rand:      &corefakes.FakeRand{},
+		Clock:     utils.ImmediatelyClock,
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- botOperator.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return botOperator.Status().Status == models.BotStatusPlaying
+	}, time.Second, time.Millisecond*10)
+
+	botOperator.Drain()
+
+	// The snake is alive, so the bot keeps playing.
+	select {
+	case <-done:
+		t.Fatal("bot stopped with a living snake")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	botEngine.SnakeReturns(5, 0, false)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("bot is not drained")
+	}
+
+	require.Equal(t, 1, connector.ConnectCallCount())
+	require.Equal(t, 1, connection.CloseCallCount())
+	require.Equal(t, models.BotStatusStopped, botOperator.Status().Status)
+}
+
+func Test_BotOperator_DrainWithoutSnake(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = utils.WithLogger(ctx, utils.DiscardEntry)
+
+	botOperator := core.NewBotOperator(&core.BotOperatorParams{
+		GameId:    99,
+		Connector: &corefakes.FakeConnector{},
+		BotEngine: &corefakes.FakeBotEngine{},
+		Parser:    &corefakes.FakeParser{},
+		Rand:      &corefakes.FakeRand{},
+		Clock:     utils.NeverClock,
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- botOperator.Run(ctx)
+	}()
+
+	botOperator.Drain()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("bot is not drained")
+	}
+}
+
+func Test_BotOperator_WatchStop(t *testing.T) {
+	watched := make(chan context.Context, 1)
+	botEngine := &corefakes.FakeBotEngine{
+		WatchStub: func(ctx context.Context) <-chan *models.BotView {
+			watched <- ctx
+			return nil
+		},
+	}
+
+	botOperator := core.NewBotOperator(&core.BotOperatorParams{
+		GameId:    99,
+		Connector: &corefakes.FakeConnector{},
+		BotEngine: botEngine,
+		Parser:    &corefakes.FakeParser{},
+		Rand:      &corefakes.FakeRand{},
+		Clock:     utils.NeverClock,
+	})
+
+	botOperator.Watch(context.Background())
+	ctx := <-watched
+	require.NoError(t, ctx.Err())
+
+	// Stopping the bot closes the views of its watchers.
+	botOperator.Stop()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("watching is not stopped")
+	}
+}
+
+type recordingClock struct {
+	mux    sync.Mutex
+	delays []time.Duration
+}
+
+func (c *recordingClock) After(d time.Duration) <-chan time.Time {
+	c.mux.Lock()
+	c.delays = append(c.delays, d)
+	c.mux.Unlock()
+	return utils.ImmediatelyClock.After(d)
+}
+
+func (c *recordingClock) Now() time.Time {
+	return time.Now()
+}
+
+func Test_BotOperator_CircuitOpen(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testBotOperatorTimeout)
+	defer cancel()
+
+	ctx = utils.WithLogger(ctx, utils.DiscardEntry)
+
+	open := errors.Wrap(&connect.CircuitOpenError{
+		Cooldown: time.Second * 30,
+	}, "failed to connect")
+	connector := &corefakes.FakeConnector{}
+	connector.ConnectReturnsOnCall(0, nil, open)
+	connector.ConnectReturnsOnCall(1, nil, errors.New("connection refused"))
+	connector.ConnectReturnsOnCall(2, nil, open)
+	connector.ConnectReturnsOnCall(3, nil, connect.ErrGameNotFound)
+
+	bus := core.NewBus()
+	events := bus.Subscribe(ctx)
+	clock := &recordingClock{}
+
+	botOperator := core.NewBotOperator(&core.BotOperatorParams{
+		Events:    bus,
+		GameId:    99,
+		Connector: connector,
+		BotEngine: &corefakes.FakeBotEngine{},
+		Parser:    &corefakes.FakeParser{},
+		Rand:      &corefakes.FakeRand{},
+		Clock:     clock,
+	})
+
+	err := botOperator.Run(ctx)
+	require.ErrorIs(t, err, connect.ErrGameNotFound)
+
+	// The bot waits out the cooldown and the backoff does not grow.
+	require.Equal(t, []time.Duration{
+		time.Second * 3,
+		time.Second * 30,
+		time.Second,
+		time.Second * 30,
+	}, clock.delays)
+
+	reconnecting := 0
+	for event := range events {
+		if event.Type == models.EventReconnecting {
+			require.Equal(t, "connection refused", event.Error)
+			reconnecting++
+		}
+		if event.Type == models.EventStopped {
+			break
+		}
+	}
+	require.Equal(t, 1, reconnecting)
+}
@@ -1,30 +1,63 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"maps"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 	"github.com/ivan1993spb/snake-bot/internal/utils"
 )
 
 //counterfeiter:generate . BotOperator
 type BotOperator interface {
-	Run(ctx context.Context)
+	// Run runs the bot until it is stopped. It returns an error if
+	// the bot cannot play anymore, e.g. connect.ErrGameNotFound.
+	Run(ctx context.Context) error
 	Stop()
+	// Drain stops the bot once its snake dies. A bot which has no snake
+	// in the game stops at once.
+	Drain()
+	// Seed returns the seed of the bot's random decisions.
+	Seed() int64
+	// Id returns the id of the bot.
+	Id() int
+	// Status returns the current status of the bot.
+	Status() *models.BotStatus
+	// Watch returns a channel of the views of the bot. The channel is
+	// closed when the context is done.
+	Watch(ctx context.Context) <-chan *models.BotView
+	// RenderPNG draws what the bot has seen and decided on the last
+	// tick.
+	RenderPNG(w io.Writer) error
 }
 
 //counterfeiter:generate . BotOperatorFactory
 type BotOperatorFactory interface {
-	New(gameId int) BotOperator
+	// New creates a bot operator for the game. An empty profile
+	// stands for the default one. An empty discoverer stands for
+	// the one of the profile or the configured one.
+	New(gameId int, profile, discoverer string) BotOperator
+}
+
+type ProfileSet interface {
+	Has(name string) bool
 }
 
 type stateRquest struct {
-	state  map[int]int
-	result chan<- map[int]int
+	state map[int]int
+	// settings are applied together with the state. Nil keeps
+	// the settings of the games.
+	settings map[int]*models.GameSettings
+	result   chan<- map[int]int
 }
 
 type Core struct {
@@ -32,6 +65,10 @@ type Core struct {
 	wg   sync.WaitGroup
 	bots map[int][]BotOperator
 
+	// settings are the profiles and the discoverers of the games.
+	settings    map[int]*models.GameSettings
+	profilesSet ProfileSet
+
 	botsLimit int
 
 	applyStateCh chan *stateRquest
@@ -40,6 +77,28 @@ type Core struct {
 	clock   utils.Clock
 
 	storage Storage
+
+	games GamesClient
+	// removed are the games the target server does not have.
+	removed map[int]*models.RemovedGame
+
+	events *Bus
+
+	fill *models.FillPolicy
+	// fillSaved is set once the fill policy is set through the API or
+	// loaded from the storage. Such a policy is saved to the storage.
+	fillSaved    bool
+	fillInterval time.Duration
+	fillCh       chan struct{}
+
+	// drain is set once the core starts draining. No bots are spawned
+	// afterwards.
+	drain        *models.Drain
+	drainTimeout time.Duration
+	drained      chan struct{}
+
+	restartCh  chan []*restartBot
+	restarting bool
 }
 
 type Params struct {
@@ -47,14 +106,46 @@ type Params struct {
 	BotOperatorFactory
 	utils.Clock
 	Storage
+	// Profiles is optional. If it is set, the requested profiles
+	// are checked against it.
+	Profiles ProfileSet
+	// Games is optional. It lists the games of the target server.
+	Games GamesClient
+	// FillInterval is the interval between the checks of the games
+	// against the fill policy.
+	FillInterval time.Duration
+	// Events is optional. It delivers the events of the bots to
+	// the subscribers.
+	Events *Bus
+	// DrainTimeout is the time the bots are given to finish the lives of
+	// their snakes on drain.
+	DrainTimeout time.Duration
 }
 
 const applyStateChSize = 100
 
 func NewCore(params *Params) *Core {
+	fillInterval := params.FillInterval
+	if fillInterval <= 0 {
+		fillInterval = defaultFillInterval
+	}
+
+	events := params.Events
+	if events == nil {
+		events = NewBus()
+	}
+
+	drainTimeout := params.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = defaultDrainTimeout
+	}
+
 	return &Core{
 		bots: make(map[int][]BotOperator),
 
+		settings:    make(map[int]*models.GameSettings),
+		profilesSet: params.Profiles,
+
 		botsLimit: params.BotsLimit,
 
 		applyStateCh: make(chan *stateRquest, applyStateChSize),
@@ -63,6 +154,19 @@ func NewCore(params *Params) *Core {
 		clock:   params.Clock,
 
 		storage: params.Storage,
+
+		games:   params.Games,
+		removed: make(map[int]*models.RemovedGame),
+
+		events: events,
+
+		fillInterval: fillInterval,
+		fillCh:       make(chan struct{}, 1),
+
+		drainTimeout: drainTimeout,
+		drained:      make(chan struct{}),
+
+		restartCh: make(chan []*restartBot, 1),
 	}
 }
 
@@ -94,6 +198,20 @@ func (c *Core) Run(ctx context.Context) <-chan struct{} {
 		// Preload state from storage
 		c.preloadState(ctx)
 
+		fillDone := make(chan struct{})
+		go func() {
+			defer close(fillDone)
+			c.runFill(ctx)
+		}()
+		// The filling sends states to the core, so it has to stop first.
+		defer func() {
+			<-fillDone
+		}()
+
+		// Restarts start bots, so they have to stop before the bots.
+		var restarts sync.WaitGroup
+		defer restarts.Wait()
+
 		for {
 			select {
 			case <-ctx.Done():
@@ -103,8 +221,14 @@ func (c *Core) Run(ctx context.Context) <-chan struct{} {
 
 				// TODO: Consider returning error from applyState and
 				//       sending it to the caller.
-				result := c.applyState(ctx, req.state)
+				result := c.applyState(ctx, req.state, req.settings)
 				c.sendResult(ctx, req.result, result)
+			case bots := <-c.restartCh:
+				restarts.Add(1)
+				go func() {
+					defer restarts.Done()
+					c.restart(ctx, bots)
+				}()
 			}
 		}
 	}()
@@ -117,57 +241,83 @@ func (c *Core) preloadState(ctx context.Context) {
 
 	log.Info("loading state from storage")
 
-	state, err := c.storage.Load(ctx)
+	snapshot, err := c.storage.Load(ctx)
 	if err != nil {
 		log.WithError(err).Error("failed to load state from storage")
 		return
 	}
 
-	if stateBotsNumber(state) > c.botsLimit {
+	if snapshot.Fill != nil {
+		c.loadFillPolicy(ctx, snapshot.Fill)
+	}
+
+	if stateBotsNumber(snapshot.State) > c.botsLimit {
 		log.WithField("bots_limit", c.botsLimit).Error("loaded state exceeds bots limit")
 		return
 	}
 
-	c.applyState(ctx, state)
+	if err := c.validateSettings(snapshot.Settings); err != nil {
+		log.WithError(err).Error("loaded settings are invalid")
+		return
+	}
+
+	c.applyState(ctx, snapshot.State, snapshot.Settings)
 }
 
-func (c *Core) applyState(ctx context.Context, state map[int]int) map[int]int {
+// applyState sets the settings of the games and then brings the bots
+// to the state. If the result cannot be saved, both are reverted.
+func (c *Core) applyState(ctx context.Context, state map[int]int,
+	settings map[int]*models.GameSettings) map[int]int {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
 	log := utils.GetLogger(ctx)
 
+	if c.drain != nil {
+		log.Warn("the core is draining, the state is not applied")
+		return c.unsafeGetState()
+	}
+
 	log.Info("applying new state")
 
 	oldState := c.unsafeGetState()
+	oldSettings := c.unsafeGetSettings()
+
+	// The settings go first for the new bots to take them.
+	c.unsafeSetSettings(settings)
+
 	// Only the diff is applied to the state to avoid unnecessary
 	// restarts
 	d := diff(oldState, state)
-	if len(d) == 0 {
-		log.Info("no changes in state")
-		return state
+	if len(d) > 0 {
+		add, remove := diffStats(d)
+		log.WithFields(logrus.Fields{
+			"add":    add,
+			"remove": remove,
+		}).Info("applying diff to the current state")
+		c.unsafeApplyDiff(ctx, d)
 	}
+	c.unsafeDropSettings()
 
-	add, remove := diffStats(d)
-	log.WithFields(logrus.Fields{
-		"add":    add,
-		"remove": remove,
-	}).Info("applying diff to the current state")
-	c.unsafeApplyDiff(ctx, d)
+	if len(d) == 0 && maps.EqualFunc(oldSettings, c.settings,
+		(*models.GameSettings).Equal) {
+		log.Info("no changes in state")
+		return oldState
+	}
 
 	// Save the new state
-	state = c.unsafeGetState()
-	err := c.storage.Save(ctx, state)
+	err := c.storage.Save(ctx, c.unsafeSnapshot())
 	if err != nil {
 		log.WithError(err).Error("failed to save state to storage")
 
 		log.Info("reverting changes")
+		c.settings = oldSettings
 		c.unsafeApplyDiff(ctx, invertDiff(d))
 
 		return oldState
 	}
 
-	return state
+	return c.unsafeGetState()
 }
 
 func (c *Core) sendResult(
@@ -193,18 +343,44 @@ func (c *Core) unsafeGetState() map[int]int {
 	return state
 }
 
+// unsafeSnapshot returns the part of the state kept by the storage.
+func (c *Core) unsafeSnapshot() *Snapshot {
+	return &Snapshot{
+		State:    c.unsafeGetState(),
+		Settings: c.unsafeGetSettings(),
+		Fill:     c.unsafeSavedFillPolicy(),
+	}
+}
+
 var ErrRequestedTooManyBots = errors.New("requested too many bots")
 
 func (c *Core) SetState(ctx context.Context, state map[int]int) (map[int]int, error) {
+	return c.SetGames(ctx, state, nil)
+}
+
+// SetGames applies the state together with the settings of the games.
+// Nothing is applied if either is invalid. Nil settings keep
+// the settings of the games.
+func (c *Core) SetGames(ctx context.Context, state map[int]int,
+	settings map[int]*models.GameSettings) (map[int]int, error) {
 	if stateBotsNumber(state) > c.botsLimit {
 		return nil, ErrRequestedTooManyBots
 	}
 
+	if err := c.validateSettings(settings); err != nil {
+		return nil, err
+	}
+
+	if c.isDraining() {
+		return nil, ErrDraining
+	}
+
 	ch := make(chan map[int]int, 1)
 
 	req := &stateRquest{
-		state:  state,
-		result: ch,
+		state:    state,
+		settings: settings,
+		result:   ch,
 	}
 
 	select {
@@ -232,35 +408,201 @@ func (c *Core) unsafeApplyDiff(ctx context.Context, d map[int]int) {
 }
 
 func (c *Core) unsafeSpawn(ctx context.Context, gameId, bots int) {
-	c.wg.Add(bots)
-
 	for i := 0; i < bots; i++ {
-		// Initialize new bot
-		bot := c.factory.New(gameId)
-
-		// Start bot
-		go func(gameId int) {
-			defer c.wg.Done()
-
-			bot.Run(utils.WithField(ctx, "game", gameId))
-		}(gameId)
-
+		bot := c.unsafeStart(ctx, gameId, len(c.bots[gameId]))
 		c.bots[gameId] = append(c.bots[gameId], bot)
 	}
+
+	// The game is back.
+	delete(c.removed, gameId)
+
+	setBotsRunning(gameId, len(c.bots[gameId]))
 }
 
+// unsafeTerminate stops the last bots of the game. The bots are picked
+// from the tail so that the rest keep the profiles of their positions.
 func (c *Core) unsafeTerminate(ctx context.Context, gameId, bots int) {
 	for i := 0; i < bots && len(c.bots[gameId]) > 0; i++ {
-		c.bots[gameId][0].Stop()
-		c.bots[gameId] = c.bots[gameId][1:]
+		last := len(c.bots[gameId]) - 1
+		c.bots[gameId][last].Stop()
+		c.bots[gameId] = c.bots[gameId][:last]
 	}
 
 	if len(c.bots[gameId]) == 0 {
 		delete(c.bots, gameId)
 	}
+
+	setBotsRunning(gameId, len(c.bots[gameId]))
+}
+
+// unsafeStart creates the i-th bot of the game and runs it.
+func (c *Core) unsafeStart(ctx context.Context, gameId, i int) BotOperator {
+	c.wg.Add(1)
+
+	var profile, discoverer string
+	if settings, ok := c.settings[gameId]; ok {
+		if len(settings.Profiles) > 0 {
+			profile = settings.Profiles[i%len(settings.Profiles)]
+		}
+		discoverer = settings.Discoverer
+	}
+
+	bot := c.factory.New(gameId, profile, discoverer)
+
+	go func() {
+		defer c.wg.Done()
+
+		err := bot.Run(utils.WithField(ctx, "game", gameId))
+		if errors.Is(err, connect.ErrGameNotFound) {
+			c.removeGame(ctx, gameId)
+		}
+	}()
+
+	return bot
+}
+
+var (
+	ErrUnknownProfile    = errors.New("unknown profile")
+	ErrUnknownDiscoverer = errors.New("unknown discoverer")
+)
+
+func (c *Core) validateSettings(settings map[int]*models.GameSettings) error {
+	for _, s := range settings {
+		if s.Discoverer != "" && !bot.IsDiscoverer(s.Discoverer) {
+			return errors.Wrapf(ErrUnknownDiscoverer, "discoverer %q",
+				s.Discoverer)
+		}
+
+		if c.profilesSet == nil {
+			continue
+		}
+
+		for _, name := range s.Profiles {
+			if !c.profilesSet.Has(name) {
+				return errors.Wrapf(ErrUnknownProfile, "profile %q", name)
+			}
+		}
+	}
+
+	return nil
 }
 
-func (c *Core) SetOne(ctx context.Context, gameId, bots int) (map[int]int, error) {
+// unsafeSetSettings assigns the settings to the games. The bots of
+// a game get the profiles in turn. Empty settings reset the game to
+// the default ones. The settings apply to the bots started afterwards.
+func (c *Core) unsafeSetSettings(settings map[int]*models.GameSettings) {
+	for gameId, s := range settings {
+		if s.IsZero() {
+			delete(c.settings, gameId)
+			continue
+		}
+		c.settings[gameId] = s.Copy()
+	}
+}
+
+// unsafeDropSettings forgets the settings of the games with no bots.
+func (c *Core) unsafeDropSettings() {
+	for gameId := range c.settings {
+		if len(c.bots[gameId]) == 0 {
+			delete(c.settings, gameId)
+		}
+	}
+}
+
+func (c *Core) unsafeGetSettings() map[int]*models.GameSettings {
+	settings := make(map[int]*models.GameSettings, len(c.settings))
+	for gameId, s := range c.settings {
+		settings[gameId] = s.Copy()
+	}
+	return settings
+}
+
+// GetSettings returns the profiles and the discoverers of the games.
+func (c *Core) GetSettings(ctx context.Context) map[int]*models.GameSettings {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.unsafeGetSettings()
+}
+
+// GetSeeds returns the seeds of the running bots in the order the bots
+// have been started.
+func (c *Core) GetSeeds(ctx context.Context) map[int][]int64 {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	seeds := make(map[int][]int64, len(c.bots))
+	for gameId, bots := range c.bots {
+		for _, bot := range bots {
+			seeds[gameId] = append(seeds[gameId], bot.Seed())
+		}
+	}
+	return seeds
+}
+
+// Subscribe returns a channel of the events of the bots. The channel is
+// closed when the context is done.
+func (c *Core) Subscribe(ctx context.Context) <-chan *models.Event {
+	return c.events.Subscribe(ctx)
+}
+
+// GetBots returns the statuses of the bots of the game in the order the
+// bots have been started.
+func (c *Core) GetBots(ctx context.Context, gameId int) []*models.BotStatus {
+	c.mux.Lock()
+	bots := append([]BotOperator(nil), c.bots[gameId]...)
+	c.mux.Unlock()
+
+	statuses := make([]*models.BotStatus, 0, len(bots))
+	for _, bot := range bots {
+		statuses = append(statuses, bot.Status())
+	}
+	return statuses
+}
+
+var ErrBotNotFound = errors.New("bot not found")
+
+func (c *Core) findBot(gameId, botId int) (BotOperator, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	for _, bot := range c.bots[gameId] {
+		if bot.Id() == botId {
+			return bot, true
+		}
+	}
+	return nil, false
+}
+
+// WatchBot returns a channel of the views of the bot of the game.
+func (c *Core) WatchBot(ctx context.Context, gameId, botId int) (
+	<-chan *models.BotView, error) {
+	bot, ok := c.findBot(gameId, botId)
+	if !ok {
+		return nil, ErrBotNotFound
+	}
+	return bot.Watch(ctx), nil
+}
+
+// RenderBot returns a PNG image of what the bot of the game has seen and
+// decided on the last tick.
+func (c *Core) RenderBot(ctx context.Context, gameId, botId int) (
+	[]byte, error) {
+	bot, ok := c.findBot(gameId, botId)
+	if !ok {
+		return nil, ErrBotNotFound
+	}
+
+	var buf bytes.Buffer
+	if err := bot.RenderPNG(&buf); err != nil {
+		return nil, errors.Wrap(err, "render bot")
+	}
+	return buf.Bytes(), nil
+}
+
+// SetOne sets the number of the bots in the game. Nil settings keep
+// the settings of the game.
+func (c *Core) SetOne(ctx context.Context, gameId, bots int,
+	settings *models.GameSettings) (map[int]int, error) {
 	state := c.GetState(ctx)
 	state[gameId] = bots
 
@@ -268,7 +610,14 @@ func (c *Core) SetOne(ctx context.Context, gameId, bots int) (map[int]int, error
 		return nil, ErrRequestedTooManyBots
 	}
 
-	return c.SetState(ctx, state)
+	var gameSettings map[int]*models.GameSettings
+	if settings != nil {
+		gameSettings = map[int]*models.GameSettings{
+			gameId: settings,
+		}
+	}
+
+	return c.SetGames(ctx, state, gameSettings)
 }
 
 func (c *Core) GetState(ctx context.Context) map[int]int {
@@ -2,14 +2,21 @@ package core_test
 
 import (
 	"context"
+	"errors"
+	"io"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ivan1993spb/snake-bot/internal/bot"
 	"github.com/ivan1993spb/snake-bot/internal/config"
+	"github.com/ivan1993spb/snake-bot/internal/connect"
 	"github.com/ivan1993spb/snake-bot/internal/core"
 	"github.com/ivan1993spb/snake-bot/internal/core/corefakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 	"github.com/ivan1993spb/snake-bot/internal/utils"
 )
 
@@ -55,7 +62,7 @@ func Test_Core(t *testing.T) {
 
 	t.Run("change first one", func(t *testing.T) {
 		state[1] = 10
-		actual, err := c.SetOne(ctx, 1, 10)
+		actual, err := c.SetOne(ctx, 1, 10, nil)
 		require.NoError(t, err)
 		require.Equal(t, state, actual)
 		require.Equal(t, state, c.GetState(ctx))
@@ -100,7 +107,7 @@ func Test_Core(t *testing.T) {
 	})
 
 	t.Run("change one exceed limit", func(t *testing.T) {
-		actual, err := c.SetOne(ctx, 7, botsLimit)
+		actual, err := c.SetOne(ctx, 7, botsLimit, nil)
 		require.Error(t, err)
 		require.ErrorIs(t, err, core.ErrRequestedTooManyBots)
 		require.Nil(t, actual)
@@ -109,3 +116,894 @@ func Test_Core(t *testing.T) {
 		require.Equal(t, callCount, factory.NewCallCount())
 	})
 }
+
+type profileSet map[string]struct{}
+
+func (s profileSet) Has(name string) bool {
+	_, ok := s[name]
+	return ok
+}
+
+func Test_Core_Settings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewReturns(&corefakes.FakeBotOperator{})
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+		Profiles: profileSet{
+			"greedy": {},
+			"hunter": {},
+		},
+	})
+	c.Run(ctx)
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := c.SetGames(ctx, map[int]int{
+			1: 3,
+		}, map[int]*models.GameSettings{
+			1: {Profiles: []string{"greedy", "unknown"}},
+		})
+		require.ErrorIs(t, err, core.ErrUnknownProfile)
+		require.Empty(t, c.GetSettings(ctx))
+		require.Empty(t, c.GetState(ctx))
+		require.Zero(t, factory.NewCallCount())
+	})
+
+	t.Run("unknown discoverer", func(t *testing.T) {
+		_, err := c.SetGames(ctx, map[int]int{
+			1: 3,
+		}, map[int]*models.GameSettings{
+			1: {Discoverer: "compass"},
+		})
+		require.ErrorIs(t, err, core.ErrUnknownDiscoverer)
+		require.Empty(t, c.GetSettings(ctx))
+		require.Empty(t, c.GetState(ctx))
+		require.Zero(t, factory.NewCallCount())
+	})
+
+	t.Run("profiles in turn", func(t *testing.T) {
+		_, err := c.SetGames(ctx, map[int]int{
+			1: 3,
+			2: 1,
+		}, map[int]*models.GameSettings{
+			1: {
+				Profiles:   []string{"greedy", "hunter"},
+				Discoverer: bot.DiscovererMCTS,
+			},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 4, factory.NewCallCount())
+
+		profiles := map[int][]string{}
+		discoverers := map[int][]string{}
+		for i := 0; i < factory.NewCallCount(); i++ {
+			gameId, profile, discoverer := factory.NewArgsForCall(i)
+			profiles[gameId] = append(profiles[gameId], profile)
+			discoverers[gameId] = append(discoverers[gameId], discoverer)
+		}
+		require.Equal(t, map[int][]string{
+			1: {"greedy", "hunter", "greedy"},
+			2: {""},
+		}, profiles)
+		require.Equal(t, map[int][]string{
+			1: {bot.DiscovererMCTS, bot.DiscovererMCTS, bot.DiscovererMCTS},
+			2: {""},
+		}, discoverers)
+
+		require.Equal(t, map[int]*models.GameSettings{
+			1: {
+				Profiles:   []string{"greedy", "hunter"},
+				Discoverer: bot.DiscovererMCTS,
+			},
+		}, c.GetSettings(ctx))
+	})
+
+	t.Run("reset settings", func(t *testing.T) {
+		_, err := c.SetGames(ctx, map[int]int{
+			1: 3,
+			2: 1,
+		}, map[int]*models.GameSettings{
+			1: {},
+		})
+		require.NoError(t, err)
+		require.Empty(t, c.GetSettings(ctx))
+	})
+
+	t.Run("settings are dropped with the game", func(t *testing.T) {
+		_, err := c.SetOne(ctx, 2, 1, &models.GameSettings{
+			Profiles: []string{"hunter"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[int]*models.GameSettings{
+			2: {Profiles: []string{"hunter"}},
+		}, c.GetSettings(ctx))
+
+		_, err = c.SetState(ctx, map[int]int{
+			1: 3,
+		})
+		require.NoError(t, err)
+		require.Empty(t, c.GetSettings(ctx))
+	})
+}
+
+func Test_Core_SettingsScale(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The seeds of the bots tell their profiles apart.
+	seeds := map[string]int64{
+		"greedy": 1,
+		"hunter": 2,
+	}
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewStub = func(gameId int, profile, discoverer string) core.BotOperator {
+		bot := &corefakes.FakeBotOperator{}
+		bot.SeedReturns(seeds[profile])
+		return bot
+	}
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+		Profiles: profileSet{
+			"greedy": {},
+			"hunter": {},
+		},
+	})
+	c.Run(ctx)
+
+	settings := map[int]*models.GameSettings{
+		1: {Profiles: []string{"greedy", "hunter"}},
+	}
+
+	for _, test := range []struct {
+		bots     int
+		profiles []int64
+	}{
+		{2, []int64{1, 2}},
+		{1, []int64{1}},
+		{2, []int64{1, 2}},
+		{3, []int64{1, 2, 1}},
+		{1, []int64{1}},
+		{4, []int64{1, 2, 1, 2}},
+	} {
+		_, err := c.SetGames(ctx, map[int]int{1: test.bots}, settings)
+		require.NoError(t, err)
+		require.Equal(t, map[int][]int64{
+			1: test.profiles,
+		}, c.GetSeeds(ctx), "bots %d", test.bots)
+	}
+}
+
+type failingStorage struct {
+	core.Storage
+}
+
+func (failingStorage) Save(ctx context.Context, snapshot *core.Snapshot) error {
+	return errors.New("disk is full")
+}
+
+func Test_Core_SetGames_SaveFail(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewReturns(&corefakes.FakeBotOperator{})
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage: failingStorage{
+			Storage: core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+		},
+		Profiles: profileSet{
+			"hunter": {},
+		},
+	})
+	c.Run(ctx)
+
+	state, err := c.SetGames(ctx, map[int]int{
+		1: 2,
+	}, map[int]*models.GameSettings{
+		1: {Profiles: []string{"hunter"}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, state)
+	require.Empty(t, c.GetState(ctx))
+	require.Empty(t, c.GetSettings(ctx))
+}
+
+func Test_Core_PreloadSettings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewReturns(&corefakes.FakeBotOperator{})
+
+	storage := core.NewStorage(afero.NewMemMapFs(), config.Storage{
+		Path: "test",
+	})
+	settings := map[int]*models.GameSettings{
+		1: {
+			Profiles:   []string{"hunter", "greedy"},
+			Discoverer: bot.DiscovererAStar,
+		},
+	}
+	require.NoError(t, storage.Save(ctx, &core.Snapshot{
+		State: map[int]int{
+			1: 2,
+		},
+		Settings: settings,
+	}))
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            storage,
+	})
+	c.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		return factory.NewCallCount() == 2
+	}, time.Second, time.Millisecond*10)
+
+	_, profile, discoverer := factory.NewArgsForCall(0)
+	require.Equal(t, "hunter", profile)
+	require.Equal(t, bot.DiscovererAStar, discoverer)
+	_, profile, _ = factory.NewArgsForCall(1)
+	require.Equal(t, "greedy", profile)
+	require.Equal(t, settings, c.GetSettings(ctx))
+}
+
+func Test_Core_GetSeeds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var seed int64
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewStub = func(gameId int, profile, discoverer string) core.BotOperator {
+		seed++
+		operator := &corefakes.FakeBotOperator{}
+		operator.SeedReturns(seed)
+		return operator
+	}
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+	})
+	c.Run(ctx)
+
+	_, err := c.SetState(ctx, map[int]int{
+		1: 2,
+	})
+	require.NoError(t, err)
+	_, err = c.SetState(ctx, map[int]int{
+		1: 2,
+		3: 1,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, map[int][]int64{
+		1: {1, 2},
+		3: {3},
+	}, c.GetSeeds(ctx))
+}
+
+func Test_Core_GetGames(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewReturns(&corefakes.FakeBotOperator{})
+
+	games := &corefakes.FakeGamesClient{}
+	games.GamesReturns([]*connect.Game{
+		{Id: 1, Limit: 10, Count: 4, Width: 40, Height: 30},
+		{Id: 2, Limit: 5, Count: 0, Width: 20, Height: 20},
+	}, nil)
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+		Games:              games,
+	})
+	c.Run(ctx)
+
+	_, err := c.SetState(ctx, map[int]int{
+		1: 3,
+		7: 1,
+	})
+	require.NoError(t, err)
+
+	actual, err := c.GetGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &models.ServerGames{
+		Games: []*models.ServerGame{
+			{Id: 1, Width: 40, Height: 30, Limit: 10, Count: 4, Bots: 3},
+			{Id: 2, Width: 20, Height: 20, Limit: 5, Count: 0, Bots: 0},
+		},
+	}, actual)
+
+	games.GamesReturns(nil, errors.New("connection refused"))
+	_, err = c.GetGames(ctx)
+	require.Error(t, err)
+}
+
+func Test_Core_GetGames_NoClient(t *testing.T) {
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: &corefakes.FakeBotOperatorFactory{},
+		Clock:              utils.NeverClock,
+		Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+	})
+
+	_, err := c.GetGames(context.Background())
+	require.Equal(t, core.ErrNoGamesClient, err)
+}
+
+func newConnectedBot() *corefakes.FakeBotOperator {
+	bot := &corefakes.FakeBotOperator{}
+	bot.StatusReturns(&models.BotStatus{
+		Status: models.BotStatusPlaying,
+	})
+	return bot
+}
+
+func Test_Core_FillPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewReturns(newConnectedBot())
+
+	games := &corefakes.FakeGamesClient{}
+	games.GamesReturns([]*connect.Game{
+		{Id: 1, Limit: 10, Count: 2},
+		{Id: 2, Limit: 5, Count: 0},
+	}, nil)
+
+	storage := core.NewStorage(afero.NewMemMapFs(), config.Storage{})
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            storage,
+		Games:              games,
+	})
+	c.Run(ctx)
+
+	t.Run("invalid policy", func(t *testing.T) {
+		err := c.SetFillPolicy(ctx, &models.FillPolicy{
+			Occupancy: 1.5,
+		})
+		require.True(t, errors.Is(err, core.ErrInvalidFillPolicy))
+		require.Nil(t, c.GetFillPolicy(ctx))
+	})
+
+	t.Run("invalid bots bounds", func(t *testing.T) {
+		err := c.SetFillPolicy(ctx, &models.FillPolicy{
+			MinBots: 5,
+			MaxBots: 2,
+		})
+		require.True(t, errors.Is(err, core.ErrInvalidFillPolicy))
+		require.Nil(t, c.GetFillPolicy(ctx))
+	})
+
+	t.Run("fill", func(t *testing.T) {
+		policy := &models.FillPolicy{
+			Occupancy: 0.4,
+		}
+		require.NoError(t, c.SetFillPolicy(ctx, policy))
+		require.Equal(t, policy, c.GetFillPolicy(ctx))
+
+		require.Eventually(t, func() bool {
+			state := c.GetState(ctx)
+			return state[1] == 4 && state[2] == 2
+		}, time.Second, time.Millisecond*10)
+
+		snapshot, err := storage.Load(ctx)
+		require.NoError(t, err)
+		require.Equal(t, policy, snapshot.Fill)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		require.NoError(t, c.SetFillPolicy(ctx, nil))
+		require.Nil(t, c.GetFillPolicy(ctx))
+
+		snapshot, err := storage.Load(ctx)
+		require.NoError(t, err)
+		require.Equal(t, &models.FillPolicy{}, snapshot.Fill)
+
+		games.GamesReturns([]*connect.Game{
+			{Id: 1, Limit: 10, Count: 10},
+		}, nil)
+		time.Sleep(time.Millisecond * 50)
+		require.Equal(t, map[int]int{1: 4, 2: 2}, c.GetState(ctx))
+	})
+}
+
+func Test_Core_DefaultFillPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewReturns(newConnectedBot())
+
+	games := &corefakes.FakeGamesClient{}
+	games.GamesReturns([]*connect.Game{
+		{Id: 1, Limit: 10, Count: 0},
+	}, nil)
+
+	storage := core.NewStorage(afero.NewMemMapFs(), config.Storage{
+		Path: "test",
+	})
+	newCore := func() *core.Core {
+		c := core.NewCore(&core.Params{
+			BotsLimit:          10,
+			BotOperatorFactory: factory,
+			Clock:              utils.NeverClock,
+			Storage:            storage,
+			Games:              games,
+		})
+		require.NoError(t, c.SetDefaultFillPolicy(ctx, &models.FillPolicy{
+			Players: 2,
+		}))
+		return c
+	}
+
+	t.Run("default policy is not saved", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		c := newCore()
+		c.Run(ctx)
+
+		require.Eventually(t, func() bool {
+			return c.GetState(ctx)[1] == 2
+		}, time.Second, time.Millisecond*10)
+
+		snapshot, err := storage.Load(ctx)
+		require.NoError(t, err)
+		require.Nil(t, snapshot.Fill)
+
+		require.NoError(t, c.SetFillPolicy(ctx, &models.FillPolicy{
+			Players: 3,
+		}))
+	})
+
+	t.Run("saved policy wins", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		c := newCore()
+		c.Run(ctx)
+
+		require.Eventually(t, func() bool {
+			policy := c.GetFillPolicy(ctx)
+			return policy != nil && policy.Players == 3
+		}, time.Second, time.Millisecond*10)
+	})
+}
+
+func Test_Core_RemoveGame(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewStub = func(gameId int, profile, discoverer string) core.BotOperator {
+		bot := &corefakes.FakeBotOperator{}
+		if gameId == 7 {
+			bot.RunReturns(connect.ErrGameNotFound)
+		}
+		return bot
+	}
+
+	storage := core.NewStorage(afero.NewMemMapFs(), config.Storage{
+		Path: "test",
+	})
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            storage,
+	})
+	c.Run(ctx)
+
+	_, err := c.SetState(ctx, map[int]int{
+		1: 2,
+		7: 3,
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(c.GetState(ctx)) == 1
+	}, time.Second, time.Millisecond*10)
+	require.Equal(t, map[int]int{1: 2}, c.GetState(ctx))
+
+	snapshot, err := storage.Load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[int]int{1: 2}, snapshot.State)
+
+	removed := c.GetRemovedGames(ctx)
+	require.Len(t, removed.Games, 1)
+	require.Equal(t, 7, removed.Games[0].Game)
+	require.Equal(t, 3, removed.Games[0].Bots)
+
+	t.Run("game is back", func(t *testing.T) {
+		factory.NewReturns(&corefakes.FakeBotOperator{})
+
+		_, err := c.SetOne(ctx, 7, 1, nil)
+		require.NoError(t, err)
+		require.Empty(t, c.GetRemovedGames(ctx).Games)
+	})
+}
+
+func Test_Core_GetBots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := &corefakes.FakeBotOperatorFactory{}
+	seed := int64(0)
+	factory.NewStub = func(gameId int, profile, discoverer string) core.BotOperator {
+		seed++
+		bot := &corefakes.FakeBotOperator{}
+		bot.StatusReturns(&models.BotStatus{
+			Status: models.BotStatusPlaying,
+			Seed:   seed,
+		})
+		return bot
+	}
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+	})
+	c.Run(ctx)
+
+	_, err := c.SetState(ctx, map[int]int{
+		1: 2,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []*models.BotStatus{
+		{Status: models.BotStatusPlaying, Seed: 1},
+		{Status: models.BotStatusPlaying, Seed: 2},
+	}, c.GetBots(ctx, 1))
+	require.Empty(t, c.GetBots(ctx, 2))
+}
+
+func Test_Core_WatchBot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	views := make(chan *models.BotView)
+	factory := &corefakes.FakeBotOperatorFactory{}
+	id := 0
+	factory.NewStub = func(gameId int, profile, discoverer string) core.BotOperator {
+		id++
+		bot := &corefakes.FakeBotOperator{}
+		bot.IdReturns(id)
+		bot.WatchReturns(views)
+		return bot
+	}
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+	})
+	c.Run(ctx)
+
+	_, err := c.SetState(ctx, map[int]int{
+		1: 2,
+	})
+	require.NoError(t, err)
+
+	ch, err := c.WatchBot(ctx, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, (<-chan *models.BotView)(views), ch)
+
+	_, err = c.WatchBot(ctx, 1, 3)
+	require.ErrorIs(t, err, core.ErrBotNotFound)
+	_, err = c.WatchBot(ctx, 2, 1)
+	require.ErrorIs(t, err, core.ErrBotNotFound)
+}
+
+func Test_Core_RenderBot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	factory := &corefakes.FakeBotOperatorFactory{}
+	factory.NewStub = func(gameId int, profile, discoverer string) core.BotOperator {
+		bot := &corefakes.FakeBotOperator{}
+		bot.IdReturns(1)
+		bot.RenderPNGStub = func(w io.Writer) error {
+			_, err := w.Write([]byte("image"))
+			return err
+		}
+		return bot
+	}
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.NeverClock,
+		Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+	})
+	c.Run(ctx)
+
+	_, err := c.SetState(ctx, map[int]int{
+		1: 1,
+	})
+	require.NoError(t, err)
+
+	image, err := c.RenderBot(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("image"), image)
+
+	_, err = c.RenderBot(ctx, 1, 2)
+	require.ErrorIs(t, err, core.ErrBotNotFound)
+}
+
+func Test_Core_Drain(t *testing.T) {
+	// newFactory returns a factory of bots which stop on drain if their
+	// snakes are dead.
+	newFactory := func(dead bool) (*corefakes.FakeBotOperatorFactory,
+		*[]*corefakes.FakeBotOperator) {
+		bots := []*corefakes.FakeBotOperator{}
+		factory := &corefakes.FakeBotOperatorFactory{}
+		factory.NewStub = func(gameId int, profile, discoverer string) core.BotOperator {
+			bot := &corefakes.FakeBotOperator{}
+			drain := make(chan struct{})
+			stop := make(chan struct{})
+			bot.DrainStub = func() {
+				if dead {
+					close(drain)
+				}
+			}
+			bot.StopStub = func() {
+				close(stop)
+			}
+			bot.RunStub = func(ctx context.Context) error {
+				select {
+				case <-drain:
+				case <-stop:
+				case <-ctx.Done():
+				}
+				return nil
+			}
+			bots = append(bots, bot)
+			return bot
+		}
+		return factory, &bots
+	}
+
+	t.Run("bots drained", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		factory, bots := newFactory(true)
+		storage := core.NewStorage(afero.NewMemMapFs(), config.Storage{})
+		c := core.NewCore(&core.Params{
+			BotsLimit:          10,
+			BotOperatorFactory: factory,
+			Clock:              utils.NeverClock,
+			Storage:            storage,
+		})
+		c.Run(ctx)
+
+		_, err := c.SetState(ctx, map[int]int{
+			1: 2,
+			2: 1,
+		})
+		require.NoError(t, err)
+
+		c.Drain(ctx)
+
+		select {
+		case <-c.Drained():
+		case <-time.After(time.Second):
+			t.Fatal("core is not drained")
+		}
+
+		require.Len(t, *bots, 3)
+		for _, bot := range *bots {
+			require.Equal(t, 1, bot.DrainCallCount())
+			require.Zero(t, bot.StopCallCount())
+		}
+
+		_, err = c.SetState(ctx, map[int]int{
+			1: 3,
+		})
+		require.ErrorIs(t, err, core.ErrDraining)
+
+		// The state is kept for the next start.
+		require.Equal(t, map[int]int{1: 2, 2: 1}, c.GetState(ctx))
+		snapshot, err := storage.Load(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[int]int{1: 2, 2: 1}, snapshot.State)
+	})
+
+	t.Run("drain timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		factory, bots := newFactory(false)
+		c := core.NewCore(&core.Params{
+			BotsLimit:          10,
+			BotOperatorFactory: factory,
+			Clock:              utils.RealClock,
+			Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+			DrainTimeout:       time.Millisecond * 10,
+		})
+		c.Run(ctx)
+
+		_, err := c.SetState(ctx, map[int]int{
+			1: 1,
+		})
+		require.NoError(t, err)
+
+		c.Drain(ctx)
+
+		select {
+		case <-c.Drained():
+		case <-time.After(time.Second):
+			t.Fatal("core is not drained")
+		}
+
+		require.Len(t, *bots, 1)
+		require.Equal(t, 1, (*bots)[0].StopCallCount())
+	})
+}
+
+func Test_Core_Restart(t *testing.T) {
+	// newFactory returns a factory of bots which run until they are
+	// stopped.
+	newFactory := func(status string) (*corefakes.FakeBotOperatorFactory,
+		*[]*corefakes.FakeBotOperator) {
+		bots := []*corefakes.FakeBotOperator{}
+		factory := &corefakes.FakeBotOperatorFactory{}
+		factory.NewStub = func(gameId int, profile, discoverer string) core.BotOperator {
+			id := len(bots) + 1
+			bot := &corefakes.FakeBotOperator{}
+			stop := make(chan struct{})
+			bot.IdReturns(id)
+			bot.StatusReturns(&models.BotStatus{
+				Id:     id,
+				Status: status,
+			})
+			bot.StopStub = func() {
+				close(stop)
+			}
+			bot.RunStub = func(ctx context.Context) error {
+				select {
+				case <-stop:
+				case <-ctx.Done():
+				}
+				return nil
+			}
+			bots = append(bots, bot)
+			return bot
+		}
+		return factory, &bots
+	}
+
+	botIds := func(statuses []*models.BotStatus) []int {
+		ids := make([]int, 0, len(statuses))
+		for _, status := range statuses {
+			ids = append(ids, status.Id)
+		}
+		return ids
+	}
+
+	t.Run("restart game", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		factory, bots := newFactory(models.BotStatusPlaying)
+		c := core.NewCore(&core.Params{
+			BotsLimit:          10,
+			BotOperatorFactory: factory,
+			Clock:              utils.NeverClock,
+			Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+		})
+		c.Run(ctx)
+
+		_, err := c.SetState(ctx, map[int]int{
+			1: 2,
+			2: 1,
+		})
+		require.NoError(t, err)
+		old := append([]*corefakes.FakeBotOperator(nil), (*bots)...)
+
+		state, err := c.Restart(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, map[int]int{1: 2}, state)
+
+		require.Eventually(t, func() bool {
+			return len(botIds(c.GetBots(ctx, 1))) == 2 &&
+				!slices.Contains(botIds(c.GetBots(ctx, 1)), old[0].Id()) &&
+				!slices.Contains(botIds(c.GetBots(ctx, 1)), old[1].Id())
+		}, time.Second, time.Millisecond*10)
+
+		require.Eventually(t, func() bool {
+			_, err := c.Restart(ctx, 2)
+			return err == nil
+		}, time.Second, time.Millisecond*10)
+
+		require.Eventually(t, func() bool {
+			return old[2].StopCallCount() == 1
+		}, time.Second, time.Millisecond*10)
+
+		for _, bot := range old {
+			require.Equal(t, 1, bot.StopCallCount())
+		}
+		require.Equal(t, 6, factory.NewCallCount())
+		require.Equal(t, map[int]int{1: 2, 2: 1}, c.GetState(ctx))
+	})
+
+	t.Run("restart in progress", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// The new bots never play, so the restart waits.
+		factory, _ := newFactory(models.BotStatusConnecting)
+		c := core.NewCore(&core.Params{
+			BotsLimit:          10,
+			BotOperatorFactory: factory,
+			Clock:              utils.NeverClock,
+			Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+		})
+		c.Run(ctx)
+
+		_, err := c.SetState(ctx, map[int]int{
+			1: 2,
+		})
+		require.NoError(t, err)
+
+		_, err = c.Restart(ctx, 0)
+		require.NoError(t, err)
+
+		_, err = c.Restart(ctx, 0)
+		require.ErrorIs(t, err, core.ErrRestarting)
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		factory, _ := newFactory(models.BotStatusPlaying)
+		c := core.NewCore(&core.Params{
+			BotsLimit:          10,
+			BotOperatorFactory: factory,
+			Clock:              utils.NeverClock,
+			Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+		})
+		c.Run(ctx)
+
+		c.Drain(ctx)
+
+		_, err := c.Restart(ctx, 0)
+		require.ErrorIs(t, err, core.ErrDraining)
+	})
+}
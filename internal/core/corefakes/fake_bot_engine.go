@@ -3,13 +3,26 @@ package corefakes
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 	"github.com/ivan1993spb/snake-bot/internal/types"
 )
 
 type FakeBotEngine struct {
+	RenderPNGStub        func(io.Writer) error
+	renderPNGMutex       sync.RWMutex
+	renderPNGArgsForCall []struct {
+		arg1 io.Writer
+	}
+	renderPNGReturns struct {
+		result1 error
+	}
+	renderPNGReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RunStub        func(context.Context) <-chan types.Direction
 	runMutex       sync.RWMutex
 	runArgsForCall []struct {
@@ -21,10 +34,96 @@ type FakeBotEngine struct {
 	runReturnsOnCall map[int]struct {
 		result1 <-chan types.Direction
 	}
+	SnakeStub        func() (uint32, int, bool)
+	snakeMutex       sync.RWMutex
+	snakeArgsForCall []struct {
+	}
+	snakeReturns struct {
+		result1 uint32
+		result2 int
+		result3 bool
+	}
+	snakeReturnsOnCall map[int]struct {
+		result1 uint32
+		result2 int
+		result3 bool
+	}
+	WatchStub        func(context.Context) <-chan *models.BotView
+	watchMutex       sync.RWMutex
+	watchArgsForCall []struct {
+		arg1 context.Context
+	}
+	watchReturns struct {
+		result1 <-chan *models.BotView
+	}
+	watchReturnsOnCall map[int]struct {
+		result1 <-chan *models.BotView
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeBotEngine) RenderPNG(arg1 io.Writer) error {
+	fake.renderPNGMutex.Lock()
+	ret, specificReturn := fake.renderPNGReturnsOnCall[len(fake.renderPNGArgsForCall)]
+	fake.renderPNGArgsForCall = append(fake.renderPNGArgsForCall, struct {
+		arg1 io.Writer
+	}{arg1})
+	stub := fake.RenderPNGStub
+	fakeReturns := fake.renderPNGReturns
+	fake.recordInvocation("RenderPNG", []interface{}{arg1})
+	fake.renderPNGMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBotEngine) RenderPNGCallCount() int {
+	fake.renderPNGMutex.RLock()
+	defer fake.renderPNGMutex.RUnlock()
+	return len(fake.renderPNGArgsForCall)
+}
+
+func (fake *FakeBotEngine) RenderPNGCalls(stub func(io.Writer) error) {
+	fake.renderPNGMutex.Lock()
+	defer fake.renderPNGMutex.Unlock()
+	fake.RenderPNGStub = stub
+}
+
+func (fake *FakeBotEngine) RenderPNGArgsForCall(i int) io.Writer {
+	fake.renderPNGMutex.RLock()
+	defer fake.renderPNGMutex.RUnlock()
+	argsForCall := fake.renderPNGArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBotEngine) RenderPNGReturns(result1 error) {
+	fake.renderPNGMutex.Lock()
+	defer fake.renderPNGMutex.Unlock()
+	fake.RenderPNGStub = nil
+	fake.renderPNGReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBotEngine) RenderPNGReturnsOnCall(i int, result1 error) {
+	fake.renderPNGMutex.Lock()
+	defer fake.renderPNGMutex.Unlock()
+	fake.RenderPNGStub = nil
+	if fake.renderPNGReturnsOnCall == nil {
+		fake.renderPNGReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renderPNGReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBotEngine) Run(arg1 context.Context) <-chan types.Direction {
 	fake.runMutex.Lock()
 	ret, specificReturn := fake.runReturnsOnCall[len(fake.runArgsForCall)]
@@ -86,11 +185,137 @@ func (fake *FakeBotEngine) RunReturnsOnCall(i int, result1 <-chan types.Directio
 	}{result1}
 }
 
+func (fake *FakeBotEngine) Snake() (uint32, int, bool) {
+	fake.snakeMutex.Lock()
+	ret, specificReturn := fake.snakeReturnsOnCall[len(fake.snakeArgsForCall)]
+	fake.snakeArgsForCall = append(fake.snakeArgsForCall, struct {
+	}{})
+	stub := fake.SnakeStub
+	fakeReturns := fake.snakeReturns
+	fake.recordInvocation("Snake", []interface{}{})
+	fake.snakeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBotEngine) SnakeCallCount() int {
+	fake.snakeMutex.RLock()
+	defer fake.snakeMutex.RUnlock()
+	return len(fake.snakeArgsForCall)
+}
+
+func (fake *FakeBotEngine) SnakeCalls(stub func() (uint32, int, bool)) {
+	fake.snakeMutex.Lock()
+	defer fake.snakeMutex.Unlock()
+	fake.SnakeStub = stub
+}
+
+func (fake *FakeBotEngine) SnakeReturns(result1 uint32, result2 int, result3 bool) {
+	fake.snakeMutex.Lock()
+	defer fake.snakeMutex.Unlock()
+	fake.SnakeStub = nil
+	fake.snakeReturns = struct {
+		result1 uint32
+		result2 int
+		result3 bool
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBotEngine) SnakeReturnsOnCall(i int, result1 uint32, result2 int, result3 bool) {
+	fake.snakeMutex.Lock()
+	defer fake.snakeMutex.Unlock()
+	fake.SnakeStub = nil
+	if fake.snakeReturnsOnCall == nil {
+		fake.snakeReturnsOnCall = make(map[int]struct {
+			result1 uint32
+			result2 int
+			result3 bool
+		})
+	}
+	fake.snakeReturnsOnCall[i] = struct {
+		result1 uint32
+		result2 int
+		result3 bool
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBotEngine) Watch(arg1 context.Context) <-chan *models.BotView {
+	fake.watchMutex.Lock()
+	ret, specificReturn := fake.watchReturnsOnCall[len(fake.watchArgsForCall)]
+	fake.watchArgsForCall = append(fake.watchArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.WatchStub
+	fakeReturns := fake.watchReturns
+	fake.recordInvocation("Watch", []interface{}{arg1})
+	fake.watchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBotEngine) WatchCallCount() int {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	return len(fake.watchArgsForCall)
+}
+
+func (fake *FakeBotEngine) WatchCalls(stub func(context.Context) <-chan *models.BotView) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = stub
+}
+
+func (fake *FakeBotEngine) WatchArgsForCall(i int) context.Context {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	argsForCall := fake.watchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBotEngine) WatchReturns(result1 <-chan *models.BotView) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	fake.watchReturns = struct {
+		result1 <-chan *models.BotView
+	}{result1}
+}
+
+func (fake *FakeBotEngine) WatchReturnsOnCall(i int, result1 <-chan *models.BotView) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	if fake.watchReturnsOnCall == nil {
+		fake.watchReturnsOnCall = make(map[int]struct {
+			result1 <-chan *models.BotView
+		})
+	}
+	fake.watchReturnsOnCall[i] = struct {
+		result1 <-chan *models.BotView
+	}{result1}
+}
+
 func (fake *FakeBotEngine) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.renderPNGMutex.RLock()
+	defer fake.renderPNGMutex.RUnlock()
 	fake.runMutex.RLock()
 	defer fake.runMutex.RUnlock()
+	fake.snakeMutex.RLock()
+	defer fake.snakeMutex.RUnlock()
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,36 +3,244 @@ package corefakes
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 )
 
 type FakeBotOperator struct {
-	RunStub        func(context.Context)
+	DrainStub        func()
+	drainMutex       sync.RWMutex
+	drainArgsForCall []struct {
+	}
+	IdStub        func() int
+	idMutex       sync.RWMutex
+	idArgsForCall []struct {
+	}
+	idReturns struct {
+		result1 int
+	}
+	idReturnsOnCall map[int]struct {
+		result1 int
+	}
+	RenderPNGStub        func(io.Writer) error
+	renderPNGMutex       sync.RWMutex
+	renderPNGArgsForCall []struct {
+		arg1 io.Writer
+	}
+	renderPNGReturns struct {
+		result1 error
+	}
+	renderPNGReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RunStub        func(context.Context) error
 	runMutex       sync.RWMutex
 	runArgsForCall []struct {
 		arg1 context.Context
 	}
+	runReturns struct {
+		result1 error
+	}
+	runReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SeedStub        func() int64
+	seedMutex       sync.RWMutex
+	seedArgsForCall []struct {
+	}
+	seedReturns struct {
+		result1 int64
+	}
+	seedReturnsOnCall map[int]struct {
+		result1 int64
+	}
+	StatusStub        func() *models.BotStatus
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+	}
+	statusReturns struct {
+		result1 *models.BotStatus
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 *models.BotStatus
+	}
 	StopStub        func()
 	stopMutex       sync.RWMutex
 	stopArgsForCall []struct {
 	}
+	WatchStub        func(context.Context) <-chan *models.BotView
+	watchMutex       sync.RWMutex
+	watchArgsForCall []struct {
+		arg1 context.Context
+	}
+	watchReturns struct {
+		result1 <-chan *models.BotView
+	}
+	watchReturnsOnCall map[int]struct {
+		result1 <-chan *models.BotView
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBotOperator) Run(arg1 context.Context) {
+func (fake *FakeBotOperator) Drain() {
+	fake.drainMutex.Lock()
+	fake.drainArgsForCall = append(fake.drainArgsForCall, struct {
+	}{})
+	stub := fake.DrainStub
+	fake.recordInvocation("Drain", []interface{}{})
+	fake.drainMutex.Unlock()
+	if stub != nil {
+		fake.DrainStub()
+	}
+}
+
+func (fake *FakeBotOperator) DrainCallCount() int {
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	return len(fake.drainArgsForCall)
+}
+
+func (fake *FakeBotOperator) DrainCalls(stub func()) {
+	fake.drainMutex.Lock()
+	defer fake.drainMutex.Unlock()
+	fake.DrainStub = stub
+}
+
+func (fake *FakeBotOperator) Id() int {
+	fake.idMutex.Lock()
+	ret, specificReturn := fake.idReturnsOnCall[len(fake.idArgsForCall)]
+	fake.idArgsForCall = append(fake.idArgsForCall, struct {
+	}{})
+	stub := fake.IdStub
+	fakeReturns := fake.idReturns
+	fake.recordInvocation("Id", []interface{}{})
+	fake.idMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBotOperator) IdCallCount() int {
+	fake.idMutex.RLock()
+	defer fake.idMutex.RUnlock()
+	return len(fake.idArgsForCall)
+}
+
+func (fake *FakeBotOperator) IdCalls(stub func() int) {
+	fake.idMutex.Lock()
+	defer fake.idMutex.Unlock()
+	fake.IdStub = stub
+}
+
+func (fake *FakeBotOperator) IdReturns(result1 int) {
+	fake.idMutex.Lock()
+	defer fake.idMutex.Unlock()
+	fake.IdStub = nil
+	fake.idReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBotOperator) IdReturnsOnCall(i int, result1 int) {
+	fake.idMutex.Lock()
+	defer fake.idMutex.Unlock()
+	fake.IdStub = nil
+	if fake.idReturnsOnCall == nil {
+		fake.idReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.idReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBotOperator) RenderPNG(arg1 io.Writer) error {
+	fake.renderPNGMutex.Lock()
+	ret, specificReturn := fake.renderPNGReturnsOnCall[len(fake.renderPNGArgsForCall)]
+	fake.renderPNGArgsForCall = append(fake.renderPNGArgsForCall, struct {
+		arg1 io.Writer
+	}{arg1})
+	stub := fake.RenderPNGStub
+	fakeReturns := fake.renderPNGReturns
+	fake.recordInvocation("RenderPNG", []interface{}{arg1})
+	fake.renderPNGMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBotOperator) RenderPNGCallCount() int {
+	fake.renderPNGMutex.RLock()
+	defer fake.renderPNGMutex.RUnlock()
+	return len(fake.renderPNGArgsForCall)
+}
+
+func (fake *FakeBotOperator) RenderPNGCalls(stub func(io.Writer) error) {
+	fake.renderPNGMutex.Lock()
+	defer fake.renderPNGMutex.Unlock()
+	fake.RenderPNGStub = stub
+}
+
+func (fake *FakeBotOperator) RenderPNGArgsForCall(i int) io.Writer {
+	fake.renderPNGMutex.RLock()
+	defer fake.renderPNGMutex.RUnlock()
+	argsForCall := fake.renderPNGArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBotOperator) RenderPNGReturns(result1 error) {
+	fake.renderPNGMutex.Lock()
+	defer fake.renderPNGMutex.Unlock()
+	fake.RenderPNGStub = nil
+	fake.renderPNGReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBotOperator) RenderPNGReturnsOnCall(i int, result1 error) {
+	fake.renderPNGMutex.Lock()
+	defer fake.renderPNGMutex.Unlock()
+	fake.RenderPNGStub = nil
+	if fake.renderPNGReturnsOnCall == nil {
+		fake.renderPNGReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renderPNGReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBotOperator) Run(arg1 context.Context) error {
 	fake.runMutex.Lock()
+	ret, specificReturn := fake.runReturnsOnCall[len(fake.runArgsForCall)]
 	fake.runArgsForCall = append(fake.runArgsForCall, struct {
 		arg1 context.Context
 	}{arg1})
 	stub := fake.RunStub
+	fakeReturns := fake.runReturns
 	fake.recordInvocation("Run", []interface{}{arg1})
 	fake.runMutex.Unlock()
 	if stub != nil {
-		fake.RunStub(arg1)
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	return fakeReturns.result1
 }
 
 func (fake *FakeBotOperator) RunCallCount() int {
@@ -41,7 +249,7 @@ func (fake *FakeBotOperator) RunCallCount() int {
 	return len(fake.runArgsForCall)
 }
 
-func (fake *FakeBotOperator) RunCalls(stub func(context.Context)) {
+func (fake *FakeBotOperator) RunCalls(stub func(context.Context) error) {
 	fake.runMutex.Lock()
 	defer fake.runMutex.Unlock()
 	fake.RunStub = stub
@@ -54,6 +262,135 @@ func (fake *FakeBotOperator) RunArgsForCall(i int) context.Context {
 	return argsForCall.arg1
 }
 
+func (fake *FakeBotOperator) RunReturns(result1 error) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = nil
+	fake.runReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBotOperator) RunReturnsOnCall(i int, result1 error) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = nil
+	if fake.runReturnsOnCall == nil {
+		fake.runReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.runReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBotOperator) Seed() int64 {
+	fake.seedMutex.Lock()
+	ret, specificReturn := fake.seedReturnsOnCall[len(fake.seedArgsForCall)]
+	fake.seedArgsForCall = append(fake.seedArgsForCall, struct {
+	}{})
+	stub := fake.SeedStub
+	fakeReturns := fake.seedReturns
+	fake.recordInvocation("Seed", []interface{}{})
+	fake.seedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBotOperator) SeedCallCount() int {
+	fake.seedMutex.RLock()
+	defer fake.seedMutex.RUnlock()
+	return len(fake.seedArgsForCall)
+}
+
+func (fake *FakeBotOperator) SeedCalls(stub func() int64) {
+	fake.seedMutex.Lock()
+	defer fake.seedMutex.Unlock()
+	fake.SeedStub = stub
+}
+
+func (fake *FakeBotOperator) SeedReturns(result1 int64) {
+	fake.seedMutex.Lock()
+	defer fake.seedMutex.Unlock()
+	fake.SeedStub = nil
+	fake.seedReturns = struct {
+		result1 int64
+	}{result1}
+}
+
+func (fake *FakeBotOperator) SeedReturnsOnCall(i int, result1 int64) {
+	fake.seedMutex.Lock()
+	defer fake.seedMutex.Unlock()
+	fake.SeedStub = nil
+	if fake.seedReturnsOnCall == nil {
+		fake.seedReturnsOnCall = make(map[int]struct {
+			result1 int64
+		})
+	}
+	fake.seedReturnsOnCall[i] = struct {
+		result1 int64
+	}{result1}
+}
+
+func (fake *FakeBotOperator) Status() *models.BotStatus {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+	}{})
+	stub := fake.StatusStub
+	fakeReturns := fake.statusReturns
+	fake.recordInvocation("Status", []interface{}{})
+	fake.statusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBotOperator) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *FakeBotOperator) StatusCalls(stub func() *models.BotStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *FakeBotOperator) StatusReturns(result1 *models.BotStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 *models.BotStatus
+	}{result1}
+}
+
+func (fake *FakeBotOperator) StatusReturnsOnCall(i int, result1 *models.BotStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 *models.BotStatus
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 *models.BotStatus
+	}{result1}
+}
+
 func (fake *FakeBotOperator) Stop() {
 	fake.stopMutex.Lock()
 	fake.stopArgsForCall = append(fake.stopArgsForCall, struct {
@@ -78,13 +415,86 @@ func (fake *FakeBotOperator) StopCalls(stub func()) {
 	fake.StopStub = stub
 }
 
+func (fake *FakeBotOperator) Watch(arg1 context.Context) <-chan *models.BotView {
+	fake.watchMutex.Lock()
+	ret, specificReturn := fake.watchReturnsOnCall[len(fake.watchArgsForCall)]
+	fake.watchArgsForCall = append(fake.watchArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.WatchStub
+	fakeReturns := fake.watchReturns
+	fake.recordInvocation("Watch", []interface{}{arg1})
+	fake.watchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBotOperator) WatchCallCount() int {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	return len(fake.watchArgsForCall)
+}
+
+func (fake *FakeBotOperator) WatchCalls(stub func(context.Context) <-chan *models.BotView) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = stub
+}
+
+func (fake *FakeBotOperator) WatchArgsForCall(i int) context.Context {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	argsForCall := fake.watchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBotOperator) WatchReturns(result1 <-chan *models.BotView) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	fake.watchReturns = struct {
+		result1 <-chan *models.BotView
+	}{result1}
+}
+
+func (fake *FakeBotOperator) WatchReturnsOnCall(i int, result1 <-chan *models.BotView) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	if fake.watchReturnsOnCall == nil {
+		fake.watchReturnsOnCall = make(map[int]struct {
+			result1 <-chan *models.BotView
+		})
+	}
+	fake.watchReturnsOnCall[i] = struct {
+		result1 <-chan *models.BotView
+	}{result1}
+}
+
 func (fake *FakeBotOperator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	fake.idMutex.RLock()
+	defer fake.idMutex.RUnlock()
+	fake.renderPNGMutex.RLock()
+	defer fake.renderPNGMutex.RUnlock()
 	fake.runMutex.RLock()
 	defer fake.runMutex.RUnlock()
+	fake.seedMutex.RLock()
+	defer fake.seedMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
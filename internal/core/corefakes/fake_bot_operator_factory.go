@@ -8,10 +8,12 @@ import (
 )
 
 type FakeBotOperatorFactory struct {
-	NewStub        func(int) core.BotOperator
+	NewStub        func(int, string, string) core.BotOperator
 	newMutex       sync.RWMutex
 	newArgsForCall []struct {
 		arg1 int
+		arg2 string
+		arg3 string
 	}
 	newReturns struct {
 		result1 core.BotOperator
@@ -23,18 +25,20 @@ type FakeBotOperatorFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBotOperatorFactory) New(arg1 int) core.BotOperator {
+func (fake *FakeBotOperatorFactory) New(arg1 int, arg2 string, arg3 string) core.BotOperator {
 	fake.newMutex.Lock()
 	ret, specificReturn := fake.newReturnsOnCall[len(fake.newArgsForCall)]
 	fake.newArgsForCall = append(fake.newArgsForCall, struct {
 		arg1 int
-	}{arg1})
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.NewStub
 	fakeReturns := fake.newReturns
-	fake.recordInvocation("New", []interface{}{arg1})
+	fake.recordInvocation("New", []interface{}{arg1, arg2, arg3})
 	fake.newMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -48,17 +52,17 @@ func (fake *FakeBotOperatorFactory) NewCallCount() int {
 	return len(fake.newArgsForCall)
 }
 
-func (fake *FakeBotOperatorFactory) NewCalls(stub func(int) core.BotOperator) {
+func (fake *FakeBotOperatorFactory) NewCalls(stub func(int, string, string) core.BotOperator) {
 	fake.newMutex.Lock()
 	defer fake.newMutex.Unlock()
 	fake.NewStub = stub
 }
 
-func (fake *FakeBotOperatorFactory) NewArgsForCall(i int) int {
+func (fake *FakeBotOperatorFactory) NewArgsForCall(i int) (int, string, string) {
 	fake.newMutex.RLock()
 	defer fake.newMutex.RUnlock()
 	argsForCall := fake.newArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeBotOperatorFactory) NewReturns(result1 core.BotOperator) {
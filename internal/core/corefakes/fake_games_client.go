@@ -0,0 +1,118 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package corefakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/core"
+)
+
+type FakeGamesClient struct {
+	GamesStub        func(context.Context) ([]*connect.Game, error)
+	gamesMutex       sync.RWMutex
+	gamesArgsForCall []struct {
+		arg1 context.Context
+	}
+	gamesReturns struct {
+		result1 []*connect.Game
+		result2 error
+	}
+	gamesReturnsOnCall map[int]struct {
+		result1 []*connect.Game
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeGamesClient) Games(arg1 context.Context) ([]*connect.Game, error) {
+	fake.gamesMutex.Lock()
+	ret, specificReturn := fake.gamesReturnsOnCall[len(fake.gamesArgsForCall)]
+	fake.gamesArgsForCall = append(fake.gamesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GamesStub
+	fakeReturns := fake.gamesReturns
+	fake.recordInvocation("Games", []interface{}{arg1})
+	fake.gamesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGamesClient) GamesCallCount() int {
+	fake.gamesMutex.RLock()
+	defer fake.gamesMutex.RUnlock()
+	return len(fake.gamesArgsForCall)
+}
+
+func (fake *FakeGamesClient) GamesCalls(stub func(context.Context) ([]*connect.Game, error)) {
+	fake.gamesMutex.Lock()
+	defer fake.gamesMutex.Unlock()
+	fake.GamesStub = stub
+}
+
+func (fake *FakeGamesClient) GamesArgsForCall(i int) context.Context {
+	fake.gamesMutex.RLock()
+	defer fake.gamesMutex.RUnlock()
+	argsForCall := fake.gamesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGamesClient) GamesReturns(result1 []*connect.Game, result2 error) {
+	fake.gamesMutex.Lock()
+	defer fake.gamesMutex.Unlock()
+	fake.GamesStub = nil
+	fake.gamesReturns = struct {
+		result1 []*connect.Game
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGamesClient) GamesReturnsOnCall(i int, result1 []*connect.Game, result2 error) {
+	fake.gamesMutex.Lock()
+	defer fake.gamesMutex.Unlock()
+	fake.GamesStub = nil
+	if fake.gamesReturnsOnCall == nil {
+		fake.gamesReturnsOnCall = make(map[int]struct {
+			result1 []*connect.Game
+			result2 error
+		})
+	}
+	fake.gamesReturnsOnCall[i] = struct {
+		result1 []*connect.Game
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGamesClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.gamesMutex.RLock()
+	defer fake.gamesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeGamesClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ core.GamesClient = new(FakeGamesClient)
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+const defaultDrainTimeout = time.Minute
+
+var ErrDraining = errors.New("core is draining")
+
+// Drain makes the core stop spawning bots and lets the running bots
+// finish the lives of their snakes. The bots which are still playing
+// when the drain timeout passes are stopped. The channel returned by
+// Drained is closed once all the bots are stopped. The state is kept,
+// so the bots are back when the service starts again.
+func (c *Core) Drain(ctx context.Context) *models.Drain {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.drain != nil {
+		drain := *c.drain
+		return &drain
+	}
+
+	log := utils.GetLogger(ctx)
+
+	bots := make([]BotOperator, 0, len(c.bots))
+	for _, gameBots := range c.bots {
+		bots = append(bots, gameBots...)
+	}
+
+	log.WithField("bots", len(bots)).Info("draining bots")
+
+	c.drain = &models.Drain{
+		Deadline: c.clock.Now().Add(c.drainTimeout),
+	}
+
+	for _, bot := range bots {
+		bot.Drain()
+	}
+
+	go func() {
+		defer close(c.drained)
+
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			c.wg.Wait()
+		}()
+
+		select {
+		case <-stopped:
+			log.Info("all bots drained")
+		case <-c.clock.After(c.drainTimeout):
+			log.Warn("drain timed out, stopping bots")
+			for _, bot := range bots {
+				bot.Stop()
+			}
+			<-stopped
+		}
+	}()
+
+	drain := *c.drain
+	return &drain
+}
+
+// Drained returns a channel which is closed once the core is drained.
+func (c *Core) Drained() <-chan struct{} {
+	return c.drained
+}
+
+func (c *Core) isDraining() bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.drain != nil
+}
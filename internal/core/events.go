@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+// Publisher publishes the events of bots.
+type Publisher interface {
+	Publish(event *models.Event)
+}
+
+var _ Publisher = (*Bus)(nil)
+
+const subscriberBufferSize = 256
+
+// Bus delivers the events of bots to the subscribers. A subscriber that
+// falls behind misses events rather than slows the bots down.
+type Bus struct {
+	mux         sync.Mutex
+	subscribers map[chan *models.Event]struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[chan *models.Event]struct{}),
+	}
+}
+
+func (b *Bus) Publish(event *models.Event) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel of the events published from now on. The
+// channel is closed when the context is done.
+func (b *Bus) Subscribe(ctx context.Context) <-chan *models.Event {
+	ch := make(chan *models.Event, subscriberBufferSize)
+
+	b.mux.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mux.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mux.Lock()
+		delete(b.subscribers, ch)
+		close(ch)
+		b.mux.Unlock()
+	}()
+
+	return ch
+}
+
+// botEvents publishes the events of a bot.
+type botEvents struct {
+	publisher Publisher
+	clock     utils.Clock
+	game      int
+	bot       int
+}
+
+func (e *botEvents) publish(event *models.Event) {
+	if e.publisher == nil {
+		return
+	}
+
+	event.Time = e.clock.Now()
+	event.Game = e.game
+	event.Bot = e.bot
+	e.publisher.Publish(event)
+}
+
+// botWatcher follows the snake of the bot. Snake-Server starts a countdown
+// to respawn a snake when it dies.
+type botWatcher struct {
+	bot     *bot.Bot
+	metrics *botMetrics
+	events  *botEvents
+}
+
+func (w *botWatcher) Me(id uint32) {
+	w.bot.Me(id)
+	w.events.publish(&models.Event{
+		Type:  models.EventSnake,
+		Snake: id,
+	})
+}
+
+func (w *botWatcher) Countdown(sec int) {
+	if snake, _, alive := w.bot.Snake(); alive {
+		w.metrics.death()
+		w.events.publish(&models.Event{
+			Type:  models.EventDied,
+			Snake: snake,
+		})
+	}
+	w.events.publish(&models.Event{
+		Type:      models.EventCountdown,
+		Countdown: sec,
+	})
+	w.bot.Countdown(sec)
+}
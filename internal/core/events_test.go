@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+func Test_Bus(t *testing.T) {
+	bus := NewBus()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := bus.Subscribe(ctx)
+
+	bus.Publish(&models.Event{Type: models.EventSpawned})
+	require.Equal(t, models.EventSpawned, (<-events).Type)
+
+	// A subscriber that falls behind misses events
+	for i := 0; i < subscriberBufferSize+1; i++ {
+		bus.Publish(&models.Event{Type: models.EventConnecting})
+	}
+	require.Len(t, events, subscriberBufferSize)
+
+	// The channel gets closed
+	cancel()
+	for range events {
+	}
+
+	// Publishing to nobody is fine
+	bus.Publish(&models.Event{Type: models.EventStopped})
+}
+
+func Test_botWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := NewBus()
+	events := bus.Subscribe(ctx)
+
+	profile, _ := bot.NewProfiles().Get(bot.DefaultProfileName)
+	b := bot.NewDijkstrasBot(bot.NewGame(), profile, 1)
+	w := &botWatcher{
+		bot:     b,
+		metrics: newBotMetrics(1003),
+		events: &botEvents{
+			publisher: bus,
+			clock:     utils.NeverClock,
+			game:      1003,
+			bot:       7,
+		},
+	}
+	deaths := snakeDeaths.WithLabelValues("1003")
+
+	// The countdown before the first snake
+	w.Countdown(5)
+	require.Equal(t, 0.0, testutil.ToFloat64(deaths))
+	require.Equal(t, &models.Event{
+		Type:      models.EventCountdown,
+		Game:      1003,
+		Bot:       7,
+		Countdown: 5,
+	}, <-events)
+
+	w.Me(11)
+	require.Equal(t, &models.Event{
+		Type:  models.EventSnake,
+		Game:  1003,
+		Bot:   7,
+		Snake: 11,
+	}, <-events)
+
+	w.Countdown(5)
+	require.Equal(t, 1.0, testutil.ToFloat64(deaths))
+	require.Equal(t, models.EventDied, (<-events).Type)
+	require.Equal(t, models.EventCountdown, (<-events).Type)
+
+	// Countdown goes on
+	w.Countdown(4)
+	require.Equal(t, 1.0, testutil.ToFloat64(deaths))
+	require.Equal(t, models.EventCountdown, (<-events).Type)
+}
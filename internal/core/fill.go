@@ -0,0 +1,295 @@
+package core
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+const defaultFillInterval = time.Second * 30
+
+var ErrInvalidFillPolicy = errors.New("invalid fill policy")
+
+func (c *Core) validateFillPolicy(policy *models.FillPolicy) error {
+	if policy != nil {
+		if policy.Occupancy < 0 || policy.Occupancy > 1 {
+			return errors.WithMessage(ErrInvalidFillPolicy,
+				"occupancy must be between 0 and 1")
+		}
+		if policy.Players < 0 {
+			return errors.WithMessage(ErrInvalidFillPolicy,
+				"players must not be negative")
+		}
+		if policy.MinBots < 0 || policy.MaxBots < 0 {
+			return errors.WithMessage(ErrInvalidFillPolicy,
+				"bots bounds must not be negative")
+		}
+		if policy.MaxBots > 0 && policy.MinBots > policy.MaxBots {
+			return errors.WithMessage(ErrInvalidFillPolicy,
+				"min bots must not exceed max bots")
+		}
+		if policy.Enabled() && c.games == nil {
+			return ErrNoGamesClient
+		}
+	}
+
+	return nil
+}
+
+// SetDefaultFillPolicy sets the policy the games are filled by unless
+// a policy has been set with SetFillPolicy. The default policy is not
+// saved to the storage.
+func (c *Core) SetDefaultFillPolicy(ctx context.Context,
+	policy *models.FillPolicy) error {
+	if err := c.validateFillPolicy(policy); err != nil {
+		return err
+	}
+
+	c.mux.Lock()
+	if !c.fillSaved {
+		c.fill = policy
+	}
+	c.mux.Unlock()
+
+	c.notifyFill()
+
+	return nil
+}
+
+// SetFillPolicy replaces the fill policy, saves it to the storage and
+// applies it immediately. A nil policy disables filling, the bots stay
+// where they are. The saved policy takes the place of the default one
+// on the next start.
+func (c *Core) SetFillPolicy(ctx context.Context,
+	policy *models.FillPolicy) error {
+	if err := c.validateFillPolicy(policy); err != nil {
+		return err
+	}
+
+	c.mux.Lock()
+	oldPolicy, oldSaved := c.fill, c.fillSaved
+	c.fill, c.fillSaved = policy, true
+	if err := c.storage.Save(ctx, c.unsafeSnapshot()); err != nil {
+		c.fill, c.fillSaved = oldPolicy, oldSaved
+		c.mux.Unlock()
+		return errors.Wrap(err, "save fill policy")
+	}
+	c.mux.Unlock()
+
+	c.notifyFill()
+
+	return nil
+}
+
+// unsafeSavedFillPolicy returns the policy to be saved to the storage.
+// A disabled policy is saved as an empty one, so it is told apart from
+// a policy which has never been set.
+func (c *Core) unsafeSavedFillPolicy() *models.FillPolicy {
+	if !c.fillSaved {
+		return nil
+	}
+	if c.fill == nil {
+		return &models.FillPolicy{}
+	}
+	return c.unsafeGetFillPolicy()
+}
+
+// loadFillPolicy replaces the fill policy with the saved one.
+func (c *Core) loadFillPolicy(ctx context.Context,
+	policy *models.FillPolicy) {
+	log := utils.GetLogger(ctx)
+
+	if err := c.validateFillPolicy(policy); err != nil {
+		log.WithError(err).Error("loaded fill policy is invalid")
+		return
+	}
+
+	if !policy.Enabled() {
+		policy = nil
+	}
+
+	c.mux.Lock()
+	c.fill, c.fillSaved = policy, true
+	c.mux.Unlock()
+
+	c.notifyFill()
+}
+
+func (c *Core) notifyFill() {
+	select {
+	case c.fillCh <- struct{}{}:
+	default:
+	}
+}
+
+// GetFillPolicy returns the current fill policy or nil.
+func (c *Core) GetFillPolicy(ctx context.Context) *models.FillPolicy {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.unsafeGetFillPolicy()
+}
+
+func (c *Core) unsafeGetFillPolicy() *models.FillPolicy {
+	if c.fill == nil {
+		return nil
+	}
+	policy := *c.fill
+	policy.Games = append([]int(nil), c.fill.Games...)
+	return &policy
+}
+
+// runFill reconciles the state with the fill policy periodically and
+// whenever the policy changes.
+func (c *Core) runFill(ctx context.Context) {
+	ctx = utils.WithModule(ctx, "fill")
+	log := utils.GetLogger(ctx)
+
+	for {
+		if err := c.reconcile(ctx); err != nil {
+			log.WithError(err).Error("fill fail")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.clock.After(c.fillInterval):
+		case <-c.fillCh:
+		}
+	}
+}
+
+func (c *Core) reconcile(ctx context.Context) error {
+	policy := c.GetFillPolicy(ctx)
+	if !policy.Enabled() || c.games == nil || c.isDraining() {
+		return nil
+	}
+
+	games, err := c.games.Games(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := c.GetState(ctx)
+	connected := c.connectedBots()
+	state := fillState(policy, games, current, connected, c.botsLimit)
+	if stateEqual(state, current) {
+		return nil
+	}
+
+	utils.GetLogger(ctx).WithField("state", state).Info("filling games")
+
+	_, err = c.SetState(ctx, state)
+	return err
+}
+
+// connectedBots returns the numbers of the bots which are connected to
+// the games. The server counts them among the players of the games.
+func (c *Core) connectedBots() map[int]int {
+	c.mux.Lock()
+	bots := make(map[int][]BotOperator, len(c.bots))
+	for gameId, operators := range c.bots {
+		bots[gameId] = append([]BotOperator(nil), operators...)
+	}
+	c.mux.Unlock()
+
+	connected := make(map[int]int, len(bots))
+	for gameId, operators := range bots {
+		for _, bot := range operators {
+			switch bot.Status().Status {
+			case models.BotStatusConnected, models.BotStatusPlaying,
+				models.BotStatusDead:
+				connected[gameId]++
+			}
+		}
+	}
+	return connected
+}
+
+// fillState returns the state required by the policy. The players of
+// a game but the connected bots are humans. The games out of the policy
+// keep their bots. If the bots limit is not enough, the games with lower
+// ids are filled first.
+func fillState(policy *models.FillPolicy, games []*connect.Game,
+	current, connected map[int]int, botsLimit int) map[int]int {
+	state := make(map[int]int, len(current)+len(games))
+	for gameId, bots := range current {
+		state[gameId] = bots
+	}
+
+	targets := make(map[int]int, len(games))
+	ids := make([]int, 0, len(games))
+	for _, game := range games {
+		if !policy.Covers(game.Id) {
+			continue
+		}
+		humans := game.Count - connected[game.Id]
+		if humans < 0 {
+			humans = 0
+		}
+		targets[game.Id] = fillGame(policy, game.Limit, humans)
+		ids = append(ids, game.Id)
+		delete(state, game.Id)
+	}
+	sort.Ints(ids)
+
+	budget := botsLimit - stateBotsNumber(state)
+	for _, gameId := range ids {
+		bots := targets[gameId]
+		if bots > budget {
+			bots = budget
+		}
+		if bots < 0 {
+			bots = 0
+		}
+		state[gameId] = bots
+		budget -= bots
+	}
+
+	return state
+}
+
+// fillGame returns the number of bots for a game.
+func fillGame(policy *models.FillPolicy, limit, humans int) int {
+	bots := int(math.Ceil(policy.Occupancy * float64(limit)))
+	if policy.Yield {
+		bots -= humans
+	}
+	if players := policy.Players - humans; players > bots {
+		bots = players
+	}
+	if policy.MaxBots > 0 && bots > policy.MaxBots {
+		bots = policy.MaxBots
+	}
+	if bots < policy.MinBots {
+		bots = policy.MinBots
+	}
+	// Bots never take the seats of humans.
+	if limit > 0 && bots > limit-humans {
+		bots = limit - humans
+	}
+	if bots < 0 {
+		return 0
+	}
+	return bots
+}
+
+func stateEqual(a, b map[int]int) bool {
+	for gameId, bots := range a {
+		if b[gameId] != bots {
+			return false
+		}
+	}
+	for gameId, bots := range b {
+		if a[gameId] != bots {
+			return false
+		}
+	}
+	return true
+}
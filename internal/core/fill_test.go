@@ -0,0 +1,123 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+func Test_fillState(t *testing.T) {
+	games := []*connect.Game{
+		{Id: 1, Limit: 10, Count: 0},
+		{Id: 2, Limit: 10, Count: 6},
+		{Id: 3, Limit: 4, Count: 4},
+	}
+
+	tests := []struct {
+		msg       string
+		policy    *models.FillPolicy
+		current   map[int]int
+		connected map[int]int
+		botsLimit int
+		expected  map[int]int
+	}{
+		{
+			msg:       "occupancy",
+			policy:    &models.FillPolicy{Occupancy: 0.6},
+			current:   map[int]int{},
+			connected: map[int]int{},
+			botsLimit: 100,
+			expected:  map[int]int{1: 6, 2: 4, 3: 0},
+		},
+		{
+			msg:       "occupancy counts connected bots out of humans",
+			policy:    &models.FillPolicy{Occupancy: 0.6},
+			current:   map[int]int{2: 4},
+			connected: map[int]int{2: 4},
+			botsLimit: 100,
+			expected:  map[int]int{1: 6, 2: 6, 3: 0},
+		},
+		{
+			msg:       "bots which are not connected yet are not players",
+			policy:    &models.FillPolicy{Occupancy: 0.6},
+			current:   map[int]int{2: 4},
+			connected: map[int]int{2: 1},
+			botsLimit: 100,
+			expected:  map[int]int{1: 6, 2: 5, 3: 0},
+		},
+		{
+			msg:       "players",
+			policy:    &models.FillPolicy{Players: 3},
+			current:   map[int]int{},
+			connected: map[int]int{},
+			botsLimit: 100,
+			expected:  map[int]int{1: 3, 2: 0, 3: 0},
+		},
+		{
+			msg:       "the greater requirement wins",
+			policy:    &models.FillPolicy{Occupancy: 0.2, Players: 3},
+			current:   map[int]int{},
+			connected: map[int]int{},
+			botsLimit: 100,
+			expected:  map[int]int{1: 3, 2: 2, 3: 0},
+		},
+		{
+			msg:       "bots limit",
+			policy:    &models.FillPolicy{Occupancy: 0.6},
+			current:   map[int]int{7: 2},
+			connected: map[int]int{7: 2},
+			botsLimit: 9,
+			expected:  map[int]int{1: 6, 2: 1, 3: 0, 7: 2},
+		},
+		{
+			msg:       "yield",
+			policy:    &models.FillPolicy{Occupancy: 0.8, Yield: true},
+			current:   map[int]int{2: 2},
+			connected: map[int]int{2: 2},
+			botsLimit: 100,
+			expected:  map[int]int{1: 8, 2: 4, 3: 0},
+		},
+		{
+			msg: "min and max bots",
+			policy: &models.FillPolicy{
+				Occupancy: 0.8,
+				Yield:     true,
+				MinBots:   3,
+				MaxBots:   5,
+			},
+			current:   map[int]int{},
+			connected: map[int]int{},
+			botsLimit: 100,
+			expected:  map[int]int{1: 5, 2: 3, 3: 0},
+		},
+		{
+			msg:       "min bots only",
+			policy:    &models.FillPolicy{MinBots: 2},
+			current:   map[int]int{},
+			connected: map[int]int{},
+			botsLimit: 100,
+			expected:  map[int]int{1: 2, 2: 2, 3: 0},
+		},
+		{
+			msg: "listed games",
+			policy: &models.FillPolicy{
+				Occupancy: 0.5,
+				Games:     []int{2},
+			},
+			current:   map[int]int{1: 1},
+			connected: map[int]int{1: 1},
+			botsLimit: 100,
+			expected:  map[int]int{1: 1, 2: 4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			require.Equal(t, test.expected, fillState(test.policy, games,
+				test.current, test.connected, test.botsLimit))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . GamesClient
+type GamesClient interface {
+	Games(ctx context.Context) ([]*connect.Game, error)
+}
+
+var ErrNoGamesClient = errors.New("games client is not set")
+
+// GetGames returns the games of the target server along with the numbers
+// of the bots in them.
+func (c *Core) GetGames(ctx context.Context) (*models.ServerGames, error) {
+	if c.games == nil {
+		return nil, ErrNoGamesClient
+	}
+
+	games, err := c.games.Games(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state := c.GetState(ctx)
+
+	result := &models.ServerGames{
+		Games: make([]*models.ServerGame, 0, len(games)),
+	}
+	for _, game := range games {
+		result.Games = append(result.Games, &models.ServerGame{
+			Id:     game.Id,
+			Width:  game.Width,
+			Height: game.Height,
+			Limit:  game.Limit,
+			Count:  game.Count,
+			Bots:   state[game.Id],
+		})
+	}
+
+	return result, nil
+}
+
+// removeGame stops the bots of a game the target server does not have and
+// saves the state without the game.
+func (c *Core) removeGame(ctx context.Context, gameId int) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	bots := len(c.bots[gameId])
+	if bots == 0 {
+		// Another bot of the game has already removed it.
+		return
+	}
+
+	log := utils.GetLogger(ctx).WithFields(logrus.Fields{
+		"game": gameId,
+		"bots": bots,
+	})
+	log.Warn("game not found, removing it from the state")
+
+	c.unsafeTerminate(ctx, gameId, bots)
+	c.unsafeDropSettings()
+	c.removed[gameId] = &models.RemovedGame{
+		Game:    gameId,
+		Bots:    bots,
+		Removed: c.clock.Now(),
+	}
+
+	if err := c.storage.Save(ctx, c.unsafeSnapshot()); err != nil {
+		log.WithError(err).Error("failed to save state to storage")
+	}
+}
+
+// GetRemovedGames returns the games removed from the state because the
+// target server does not have them. A game is forgotten once bots are
+// started in it again.
+func (c *Core) GetRemovedGames(ctx context.Context) *models.RemovedGames {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return models.NewRemovedGames(c.removed)
+}
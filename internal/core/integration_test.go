@@ -0,0 +1,97 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/config"
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/simulator"
+	"github.com/ivan1993spb/snake-bot/internal/snaketest"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+func Test_Core_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	server := snaketest.NewServer(snaketest.Config{
+		Games: []int{1, 2},
+		Game: simulator.Config{
+			Width:  30,
+			Height: 30,
+			Apples: 10,
+		},
+		Tick: time.Millisecond * 20,
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	profiles := bot.NewProfiles()
+	factory := &core.DefaultBotOperatorFactory{
+		Logger: utils.GetLogger(ctx),
+		Connector: connect.NewConnector(config.Target{
+			Address: server.Address(),
+		}, "test"),
+		// Bots connect without delays
+		Clock:      utils.ImmediatelyClock,
+		Profiles:   profiles,
+		Discoverer: bot.DiscovererDijkstras,
+		Seed:       1,
+	}
+
+	c := core.NewCore(&core.Params{
+		BotsLimit:          10,
+		BotOperatorFactory: factory,
+		Clock:              utils.RealClock,
+		Storage:            core.NewStorage(afero.NewMemMapFs(), config.Storage{}),
+		Profiles:           profiles,
+	})
+	done := c.Run(ctx)
+
+	state, err := c.SetState(ctx, map[int]int{
+		1: 2,
+		2: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[int]int{1: 2, 2: 1}, state)
+
+	require.Eventually(t, func() bool {
+		return server.Players(1) == 2 && server.Players(2) == 1
+	}, time.Second*5, time.Millisecond*20)
+
+	// Bots play the games
+	require.Eventually(t, func() bool {
+		return server.Commands(1) > 0 && server.Commands(2) > 0
+	}, time.Second*10, time.Millisecond*20)
+
+	_, err = c.SetState(ctx, map[int]int{
+		1: 1,
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return server.Players(1) == 1 && server.Players(2) == 0
+	}, time.Second*5, time.Millisecond*20)
+
+	// The server has no game 3
+	_, err = c.SetOne(ctx, 3, 2, nil)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		removed := c.GetRemovedGames(ctx).Games
+		return len(removed) == 1 && removed[0].Game == 3
+	}, time.Second*5, time.Millisecond*20)
+	require.Equal(t, map[int]int{1: 1}, c.GetState(ctx))
+
+	cancel()
+	<-done
+}
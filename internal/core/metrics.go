@@ -0,0 +1,160 @@
+package core
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+const (
+	metricsNamespace = "snake_bot"
+
+	labelGame      = "game"
+	labelType      = "type"
+	labelDirection = "direction"
+)
+
+var (
+	botsRunning = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "bots",
+		Help:      "Number of running bots.",
+	}, []string{labelGame})
+
+	connectionAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "connection_attempts_total",
+		Help:      "Number of connection attempts of bots.",
+	}, []string{labelGame})
+
+	connectionFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "connection_failures_total",
+		Help:      "Number of failed connection attempts of bots.",
+	}, []string{labelGame})
+
+	sessionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "session_duration_seconds",
+		Help:      "Duration of sessions of bots.",
+		// From a second to about 4.5 hours.
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{labelGame})
+
+	messagesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "messages_received_total",
+		Help:      "Number of messages received by bots.",
+	}, []string{labelGame, labelType})
+
+	messagesParsed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "messages_parsed_total",
+		Help:      "Number of messages parsed by bots.",
+	}, []string{labelGame, labelType})
+
+	messagesFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "messages_failed_total",
+		Help:      "Number of messages bots failed to parse.",
+	}, []string{labelGame, labelType})
+
+	commandsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "commands_sent_total",
+		Help:      "Number of commands sent by bots.",
+	}, []string{labelGame, labelDirection})
+
+	discoveryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "discovery_duration_seconds",
+		Help:      "Time bots take to find a path.",
+		// From 100 microseconds to about 1.6 seconds.
+		Buckets: prometheus.ExponentialBuckets(0.0001, 4, 8),
+	}, []string{labelGame})
+
+	snakeDeaths = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "snake_deaths_total",
+		Help:      "Number of deaths of snakes of bots.",
+	}, []string{labelGame})
+)
+
+const (
+	// invalidMessageType labels messages which are not valid JSON.
+	invalidMessageType = "invalid"
+	// unknownMessageType labels messages of other types, so the server
+	// cannot blow up the number of the series.
+	unknownMessageType = "unknown"
+)
+
+// botMetrics collects the metrics of the bots of a game.
+type botMetrics struct {
+	game string
+}
+
+func newBotMetrics(gameId int) *botMetrics {
+	return &botMetrics{
+		game: strconv.Itoa(gameId),
+	}
+}
+
+func (m *botMetrics) connectionAttempt() {
+	connectionAttempts.WithLabelValues(m.game).Inc()
+}
+
+func (m *botMetrics) connectionFailure() {
+	connectionFailures.WithLabelValues(m.game).Inc()
+}
+
+func (m *botMetrics) session(d time.Duration) {
+	sessionDuration.WithLabelValues(m.game).Observe(d.Seconds())
+}
+
+func (m *botMetrics) command(direction types.Direction) {
+	commandsSent.WithLabelValues(m.game, string(direction)).Inc()
+}
+
+func (m *botMetrics) discovery(d time.Duration) {
+	discoveryDuration.WithLabelValues(m.game).Observe(d.Seconds())
+}
+
+func (m *botMetrics) death() {
+	snakeDeaths.WithLabelValues(m.game).Inc()
+}
+
+// Observe implements parser.Observer.
+func (m *botMetrics) Observe(messageType types.MessageType, err error) {
+	var label string
+	switch messageType {
+	case types.MessageTypeGameEvent, types.MessageTypePlayer,
+		types.MessageTypeBroadcast:
+		label = string(messageType)
+	case "":
+		label = invalidMessageType
+	default:
+		label = unknownMessageType
+	}
+
+	messagesReceived.WithLabelValues(m.game, label).Inc()
+	if err != nil {
+		messagesFailed.WithLabelValues(m.game, label).Inc()
+	} else {
+		messagesParsed.WithLabelValues(m.game, label).Inc()
+	}
+}
+
+// setBotsRunning sets the number of the running bots of the game. A game
+// without bots is dropped.
+func setBotsRunning(gameId, bots int) {
+	game := strconv.Itoa(gameId)
+	if bots == 0 {
+		botsRunning.DeleteLabelValues(game)
+		return
+	}
+	botsRunning.WithLabelValues(game).Set(float64(bots))
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/parser"
+	"github.com/ivan1993spb/snake-bot/internal/types"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+func Test_botMetrics_Observe(t *testing.T) {
+	m := newBotMetrics(1001)
+
+	m.Observe(types.MessageTypePlayer, nil)
+	m.Observe(types.MessageTypePlayer, errors.New("parse fail"))
+	m.Observe("", errors.New("parse fail"))
+	m.Observe("whatever", errors.New("parse fail"))
+
+	require.Equal(t, 2.0, testutil.ToFloat64(
+		messagesReceived.WithLabelValues("1001", "player")))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		messagesParsed.WithLabelValues("1001", "player")))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		messagesFailed.WithLabelValues("1001", "player")))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		messagesFailed.WithLabelValues("1001", invalidMessageType)))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		messagesFailed.WithLabelValues("1001", unknownMessageType)))
+}
+
+func Test_setBotsRunning(t *testing.T) {
+	setBotsRunning(1002, 3)
+	require.Equal(t, 3.0, testutil.ToFloat64(botsRunning.WithLabelValues("1002")))
+
+	setBotsRunning(1002, 0)
+	require.False(t, botsRunning.DeleteLabelValues("1002"))
+}
+
+func Test_DefaultBotOperatorFactory_Metrics(t *testing.T) {
+	factory := &DefaultBotOperatorFactory{
+		Logger:     logrus.NewEntry(logrus.New()),
+		Clock:      utils.NeverClock,
+		Profiles:   bot.NewProfiles(),
+		Discoverer: bot.DiscovererDijkstras,
+	}
+
+	// The operator and the parser of a bot share the metrics.
+	operator := factory.New(1003, "", "").(*botOperator)
+	require.Same(t, operator.metrics,
+		operator.parser.(*parser.Parser).Observer)
+}
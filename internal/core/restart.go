@@ -0,0 +1,160 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+const (
+	// restartCheckInterval is the interval between the checks whether
+	// a new bot has started playing.
+	restartCheckInterval = time.Second
+	// restartStepTimeout bounds the wait for a new bot to start playing.
+	// The old bot is replaced anyway afterwards.
+	restartStepTimeout = time.Minute
+)
+
+var ErrRestarting = errors.New("bots are being restarted")
+
+type restartBot struct {
+	gameId int
+	bot    BotOperator
+}
+
+// Restart replaces the bots of the game with new ones one at a time.
+// A zero game id stands for all games. An old bot is stopped once its
+// replacement plays or restartStepTimeout passes, so the games keep
+// their bots. New bots take the current profiles and settings. It
+// returns the numbers of the bots to be restarted.
+func (c *Core) Restart(ctx context.Context, gameId int) (map[int]int, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.drain != nil {
+		return nil, ErrDraining
+	}
+	if c.restarting {
+		return nil, ErrRestarting
+	}
+
+	state := make(map[int]int)
+	bots := make([]*restartBot, 0)
+	for id, gameBots := range c.bots {
+		if gameId != 0 && id != gameId {
+			continue
+		}
+		state[id] = len(gameBots)
+		for _, bot := range gameBots {
+			bots = append(bots, &restartBot{
+				gameId: id,
+				bot:    bot,
+			})
+		}
+	}
+
+	if len(bots) == 0 {
+		return state, nil
+	}
+
+	// The games are restarted in turn.
+	sort.SliceStable(bots, func(i, j int) bool {
+		return bots[i].gameId < bots[j].gameId
+	})
+
+	select {
+	case c.restartCh <- bots:
+	default:
+		return nil, ErrRestarting
+	}
+	c.restarting = true
+
+	return state, nil
+}
+
+// restart replaces the bots in turn.
+func (c *Core) restart(ctx context.Context, bots []*restartBot) {
+	ctx = utils.WithModule(ctx, "restart")
+	log := utils.GetLogger(ctx)
+
+	log.WithField("bots", len(bots)).Info("restarting bots")
+
+	defer func() {
+		c.mux.Lock()
+		c.restarting = false
+		c.mux.Unlock()
+	}()
+
+	for _, old := range bots {
+		if ctx.Err() != nil {
+			return
+		}
+
+		bot, ok := c.replaceBot(ctx, old.gameId, old.bot)
+		if !ok {
+			// The bot has been removed in the meantime.
+			continue
+		}
+
+		c.waitPlaying(ctx, bot)
+		// The old bot is out of the bots the drain timeout stops, so
+		// it is stopped even if the core is draining.
+		old.bot.Stop()
+
+		log.WithFields(logrus.Fields{
+			"game": old.gameId,
+			"old":  old.bot.Id(),
+			"new":  bot.Id(),
+		}).Info("bot restarted")
+	}
+
+	log.Info("bots restarted")
+}
+
+// replaceBot starts a new bot in place of the old one. It returns false
+// if the old bot is not in the game anymore or the core is draining.
+func (c *Core) replaceBot(ctx context.Context, gameId int,
+	old BotOperator) (BotOperator, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.drain != nil {
+		return nil, false
+	}
+
+	for i, bot := range c.bots[gameId] {
+		if bot == old {
+			bot := c.unsafeStart(ctx, gameId, i)
+			c.bots[gameId][i] = bot
+			return bot, true
+		}
+	}
+
+	return nil, false
+}
+
+// waitPlaying waits until the bot plays, stops or the step times out.
+func (c *Core) waitPlaying(ctx context.Context, bot BotOperator) {
+	timeout := c.clock.After(restartStepTimeout)
+
+	for {
+		switch bot.Status().Status {
+		case models.BotStatusPlaying, models.BotStatusStopped:
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timeout:
+			return
+		case <-c.clock.After(restartCheckInterval):
+		}
+	}
+}
@@ -15,9 +15,32 @@ import (
 	"github.com/ivan1993spb/snake-bot/internal/utils"
 )
 
+// Snapshot is the part of the state of the core which outlives
+// the process: the numbers of the bots, the settings of the games and
+// the fill policy set through the API. Fill is nil if no policy has
+// been set.
+type Snapshot struct {
+	State    map[int]int
+	Settings map[int]*models.GameSettings
+	Fill     *models.FillPolicy
+}
+
+// storageDocument is the format of the state file.
+type storageDocument struct {
+	Games []*models.Game     `yaml:"games"`
+	Fill  *models.FillPolicy `yaml:"fill,omitempty"`
+}
+
+func newSnapshot() *Snapshot {
+	return &Snapshot{
+		State:    map[int]int{},
+		Settings: map[int]*models.GameSettings{},
+	}
+}
+
 type Storage interface {
-	Load(ctx context.Context) (map[int]int, error)
-	Save(ctx context.Context, state map[int]int) error
+	Load(ctx context.Context) (*Snapshot, error)
+	Save(ctx context.Context, snapshot *Snapshot) error
 	Type() string
 }
 
@@ -38,7 +61,7 @@ type storageFs struct {
 	fs   afero.Fs
 }
 
-func (s *storageFs) Load(ctx context.Context) (map[int]int, error) {
+func (s *storageFs) Load(ctx context.Context) (*Snapshot, error) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
@@ -50,27 +73,40 @@ func (s *storageFs) Load(ctx context.Context) (map[int]int, error) {
 	f, err := s.fs.Open(s.path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return map[int]int{}, nil
+			return newSnapshot(), nil
 		}
 
 		return nil, err
 	}
 	defer f.Close()
 
-	var games *models.Games
-	err = yaml.NewDecoder(f).Decode(&games)
+	var document storageDocument
+	err = yaml.NewDecoder(f).Decode(&document)
 	if err != nil {
 		if errors.Is(err, io.EOF) {
-			return map[int]int{}, nil
+			return newSnapshot(), nil
 		}
 
 		return nil, err
 	}
 
-	return games.ToMapState(), nil
+	snapshot := newSnapshot()
+	snapshot.Fill = document.Fill
+	for _, game := range document.Games {
+		snapshot.State[game.Game] = game.Bots
+		settings := &models.GameSettings{
+			Profiles:   game.Profiles,
+			Discoverer: game.Discoverer,
+		}
+		if !settings.IsZero() {
+			snapshot.Settings[game.Game] = settings
+		}
+	}
+
+	return snapshot, nil
 }
 
-func (s *storageFs) Save(ctx context.Context, state map[int]int) error {
+func (s *storageFs) Save(ctx context.Context, snapshot *Snapshot) error {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
@@ -87,7 +123,11 @@ func (s *storageFs) Save(ctx context.Context, state map[int]int) error {
 
 	enc := yaml.NewEncoder(f)
 
-	err = enc.Encode(models.NewGames(state))
+	games := models.NewGames(snapshot.State).WithSettings(snapshot.Settings)
+	err = enc.Encode(&storageDocument{
+		Games: games.Games,
+		Fill:  snapshot.Fill,
+	})
 	if err != nil {
 		return err
 	}
@@ -105,22 +145,26 @@ func (s *storageFs) Type() string {
 }
 
 type storageMem struct {
-	mux   sync.Mutex
-	state map[int]int
+	mux      sync.Mutex
+	snapshot *Snapshot
 }
 
-func (s *storageMem) Load(ctx context.Context) (map[int]int, error) {
+func (s *storageMem) Load(ctx context.Context) (*Snapshot, error) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
-	return s.state, nil
+	if s.snapshot == nil {
+		return newSnapshot(), nil
+	}
+
+	return s.snapshot, nil
 }
 
-func (s *storageMem) Save(ctx context.Context, state map[int]int) error {
+func (s *storageMem) Save(ctx context.Context, snapshot *Snapshot) error {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
-	s.state = state
+	s.snapshot = snapshot
 
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ivan1993spb/snake-bot/internal/config"
 	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 )
 
 func Test_Storage(t *testing.T) {
@@ -50,22 +51,36 @@ func Test_Storage(t *testing.T) {
 			require.Equal(t, tt.expectType, storage.Type())
 
 			t.Run("Save", func(t *testing.T) {
-				err := storage.Save(ctx, map[int]int{
-					1: 2,
-					2: 3,
-					3: 4,
+				err := storage.Save(ctx, &core.Snapshot{
+					State: map[int]int{
+						1: 2,
+						2: 3,
+						3: 4,
+					},
+					Settings: map[int]*models.GameSettings{
+						2: {
+							Profiles:   []string{"hunter", "greedy"},
+							Discoverer: "mcts",
+						},
+					},
 				})
 				require.NoError(t, err)
 			})
 
 			t.Run("Load", func(t *testing.T) {
-				state, err := storage.Load(ctx)
+				snapshot, err := storage.Load(ctx)
 				require.NoError(t, err)
 				require.Equal(t, map[int]int{
 					1: 2,
 					2: 3,
 					3: 4,
-				}, state)
+				}, snapshot.State)
+				require.Equal(t, map[int]*models.GameSettings{
+					2: {
+						Profiles:   []string{"hunter", "greedy"},
+						Discoverer: "mcts",
+					},
+				}, snapshot.Settings)
 			})
 		})
 	}
@@ -79,9 +94,10 @@ func Test_storageFs_Load_NoFile(t *testing.T) {
 		Path: "/test/some_config_file",
 	})
 
-	state, err := storage.Load(ctx)
+	snapshot, err := storage.Load(ctx)
 	require.NoError(t, err)
-	require.Empty(t, state)
+	require.Empty(t, snapshot.State)
+	require.Empty(t, snapshot.Settings)
 }
 
 func Test_storageFs_Load_EmptyFile(t *testing.T) {
@@ -97,9 +113,10 @@ func Test_storageFs_Load_EmptyFile(t *testing.T) {
 		Path: filePath,
 	})
 
-	state, err := storage.Load(ctx)
+	snapshot, err := storage.Load(ctx)
 	require.NoError(t, err)
-	require.Empty(t, state)
+	require.Empty(t, snapshot.State)
+	require.Empty(t, snapshot.Settings)
 }
 
 func Test_storageFs_Save_FileExists(t *testing.T) {
@@ -112,10 +129,12 @@ func Test_storageFs_Save_FileExists(t *testing.T) {
 		Path: filePath,
 	})
 
-	err := storage.Save(ctx, map[int]int{
-		1: 2,
-		2: 3,
-		3: 4,
+	err := storage.Save(ctx, &core.Snapshot{
+		State: map[int]int{
+			1: 2,
+			2: 3,
+			3: 4,
+		},
 	})
 	require.NoError(t, err)
 
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . AppDrain
+type AppDrain interface {
+	Drain(ctx context.Context) *models.Drain
+}
+
+type DrainHandler struct {
+	app AppDrain
+}
+
+// NewDrainHandler starts draining the service. The bots finish the lives
+// of their snakes and the service shuts down.
+func NewDrainHandler(app AppDrain) http.Handler {
+	return &DrainHandler{
+		app: app,
+	}
+}
+
+func (h *DrainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx = utils.WithModule(ctx, "drain_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("drain handler started")
+
+	respond(w, r, http.StatusAccepted, h.app.Drain(ctx))
+}
@@ -0,0 +1,41 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+func Test_DrainHandler(t *testing.T) {
+	drain := &models.Drain{
+		Deadline: time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	app := &handlersfakes.FakeAppDrain{}
+	app.DrainReturns(drain)
+
+	server := httptest.NewServer(handlers.NewDrainHandler(app))
+	defer server.Close()
+
+	req, err := http.NewRequest("POST", server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var actual *models.Drain
+	err = json.NewDecoder(resp.Body).Decode(&actual)
+	require.NoError(t, err)
+	require.Equal(t, drain, actual)
+	require.Equal(t, 1, app.DrainCallCount())
+}
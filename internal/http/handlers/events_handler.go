@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . AppEvents
+type AppEvents interface {
+	Subscribe(ctx context.Context) <-chan *models.Event
+}
+
+type EventsHandler struct {
+	app AppEvents
+}
+
+// NewEventsHandler streams the events of the bots as Server-Sent Events.
+// The optional query parameter game limits the stream to a game.
+func NewEventsHandler(app AppEvents) http.Handler {
+	return &EventsHandler{
+		app: app,
+	}
+}
+
+// eventsKeepAlive is the interval of comments which keep an idle stream
+// from being closed by proxies.
+const eventsKeepAlive = time.Second * 30
+
+func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx = utils.WithModule(ctx, "events_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("events handler started")
+	defer log.Info("events handler stopped")
+
+	gameId := 0
+	if game := r.URL.Query().Get(URLParamGame); game != "" {
+		var err error
+		gameId, err = strconv.Atoi(game)
+		if err != nil || gameId <= 0 {
+			log.WithError(err).Error("parse game id fail")
+			respondError(w, r, http.StatusBadRequest)
+			return
+		}
+	}
+
+	events := h.app.Subscribe(ctx)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	if err := rc.Flush(); err != nil {
+		log.WithError(err).Error("flush fail")
+		return
+	}
+
+	ticker := time.NewTicker(eventsKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if gameId != 0 && event.Game != gameId {
+				continue
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.WithError(err).Error("marshal event fail")
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n",
+				event.Type, data); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			log.WithError(err).Error("flush fail")
+			return
+		}
+	}
+}
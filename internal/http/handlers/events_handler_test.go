@@ -0,0 +1,74 @@
+package handlers_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+func Test_EventsHandler(t *testing.T) {
+	eventTime := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	app := &handlersfakes.FakeAppEvents{}
+	app.SubscribeStub = func(ctx context.Context) <-chan *models.Event {
+		events := make(chan *models.Event, 3)
+		events <- &models.Event{
+			Type: models.EventSpawned,
+			Time: eventTime,
+			Game: 1,
+			Bot:  1,
+		}
+		events <- &models.Event{
+			Type: models.EventSpawned,
+			Time: eventTime,
+			Game: 2,
+			Bot:  2,
+		}
+		events <- &models.Event{
+			Type:  models.EventSnake,
+			Time:  eventTime,
+			Game:  1,
+			Bot:   1,
+			Snake: 10,
+		}
+		close(events)
+		return events
+	}
+
+	server := httptest.NewServer(handlers.NewEventsHandler(app))
+	defer server.Close()
+
+	t.Run("game", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "?game=1")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "event: spawned\n"+
+			`data: {"type":"spawned","time":"2021-05-01T12:00:00Z","game":1,"bot":1}`+"\n\n"+
+			"event: snake\n"+
+			`data: {"type":"snake","time":"2021-05-01T12:00:00Z","game":1,"bot":1,"snake":10}`+"\n\n",
+			string(body))
+	})
+
+	t.Run("invalid game", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "?game=x")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . AppFill
+type AppFill interface {
+	GetFillPolicy(ctx context.Context) *models.FillPolicy
+	SetFillPolicy(ctx context.Context, policy *models.FillPolicy) error
+}
+
+type GetFillHandler struct {
+	app AppFill
+}
+
+func NewGetFillHandler(app AppFill) http.Handler {
+	return &GetFillHandler{
+		app: app,
+	}
+}
+
+func (h *GetFillHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx = utils.WithModule(ctx, "get_fill_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("get fill handler started")
+
+	policy := h.app.GetFillPolicy(ctx)
+	if policy == nil {
+		policy = &models.FillPolicy{}
+	}
+
+	respond(w, r, http.StatusOK, policy)
+}
+
+type SetFillHandler struct {
+	app AppFill
+}
+
+func NewSetFillHandler(app AppFill) http.Handler {
+	return &SetFillHandler{
+		app: app,
+	}
+}
+
+func (h *SetFillHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx = utils.WithModule(ctx, "set_fill_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("set fill handler started")
+
+	contentType := r.Header.Get("Content-type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		log.WithError(err).Error("parse media type")
+
+		respondError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	var policy *models.FillPolicy
+
+	switch mediaType {
+	case mediaTypeJson:
+		err = json.NewDecoder(r.Body).Decode(&policy)
+	case mediaTypeYaml:
+		err = yaml.NewDecoder(r.Body).Decode(&policy)
+	default:
+		log.WithField("media_type", mediaType).Error("invalid media type")
+
+		respondError(w, r, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	if err != nil || policy == nil {
+		log.WithError(err).Error("decode policy fail")
+
+		respondError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.app.SetFillPolicy(ctx, policy); err != nil {
+		log.WithError(err).Error("set fill policy fail")
+
+		if errors.Is(err, core.ErrInvalidFillPolicy) {
+			respondError(w, r, http.StatusBadRequest)
+		} else {
+			respondError(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	respond(w, r, http.StatusOK, h.app.GetFillPolicy(ctx))
+}
@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+func Test_GetFillHandler_Disabled(t *testing.T) {
+	app := &handlersfakes.FakeAppFill{}
+
+	server := httptest.NewServer(handlers.NewGetFillHandler(app))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var policy *models.FillPolicy
+	require.NoError(t, yaml.NewDecoder(resp.Body).Decode(&policy))
+	require.Equal(t, &models.FillPolicy{}, policy)
+}
+
+func Test_SetFillHandler_Json(t *testing.T) {
+	expected := &models.FillPolicy{
+		Occupancy: 0.6,
+		Games:     []int{1, 2},
+	}
+	app := &handlersfakes.FakeAppFill{}
+	app.GetFillPolicyReturns(expected)
+
+	server := httptest.NewServer(handlers.NewSetFillHandler(app))
+	defer server.Close()
+
+	resp, err := server.Client().Post(server.URL, "application/json",
+		strings.NewReader(`{"occupancy":0.6,"games":[1,2]}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 1, app.SetFillPolicyCallCount())
+	_, policy := app.SetFillPolicyArgsForCall(0)
+	require.Equal(t, expected, policy)
+
+	var actual *models.FillPolicy
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+	require.Equal(t, expected, actual)
+}
+
+func Test_SetFillHandler_Yaml(t *testing.T) {
+	app := &handlersfakes.FakeAppFill{}
+
+	server := httptest.NewServer(handlers.NewSetFillHandler(app))
+	defer server.Close()
+
+	resp, err := server.Client().Post(server.URL, "text/yaml",
+		strings.NewReader("players: 3\n"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, policy := app.SetFillPolicyArgsForCall(0)
+	require.Equal(t, &models.FillPolicy{Players: 3}, policy)
+}
+
+func Test_SetFillHandler_InvalidPolicy(t *testing.T) {
+	app := &handlersfakes.FakeAppFill{}
+	app.SetFillPolicyReturns(errors.WithMessage(core.ErrInvalidFillPolicy,
+		"occupancy"))
+
+	server := httptest.NewServer(handlers.NewSetFillHandler(app))
+	defer server.Close()
+
+	resp, err := server.Client().Post(server.URL, "application/json",
+		strings.NewReader(`{"occupancy":2}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func Test_SetFillHandler_BrokenBody(t *testing.T) {
+	app := &handlersfakes.FakeAppFill{}
+
+	server := httptest.NewServer(handlers.NewSetFillHandler(app))
+	defer server.Close()
+
+	resp, err := server.Client().Post(server.URL, "application/json",
+		strings.NewReader(`{"occupancy":`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Zero(t, app.SetFillPolicyCallCount())
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . AppGetBots
+type AppGetBots interface {
+	GetBots(ctx context.Context, gameId int) []*models.BotStatus
+}
+
+type GetBotsHandler struct {
+	app AppGetBots
+}
+
+func NewGetBotsHandler(app AppGetBots) http.Handler {
+	return &GetBotsHandler{
+		app: app,
+	}
+}
+
+// URLParamGame is the name of the URL parameter holding a game id.
+const URLParamGame = "game"
+
+func (h *GetBotsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx = utils.WithModule(ctx, "get_bots_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("get bots handler started")
+
+	gameId, err := strconv.Atoi(chi.URLParam(r, URLParamGame))
+	if err != nil || gameId <= 0 {
+		log.WithError(err).Error("parse game id fail")
+		respondError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	respond(w, r, http.StatusOK, &models.GameBots{
+		Game: gameId,
+		Bots: h.app.GetBots(ctx, gameId),
+	})
+}
@@ -0,0 +1,67 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+func Test_GetBotsHandler(t *testing.T) {
+	bots := []*models.BotStatus{
+		{
+			Status:     models.BotStatusPlaying,
+			Seed:       42,
+			Snake:      7,
+			Length:     5,
+			Started:    time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC),
+			Reconnects: 1,
+			LastError:  "connection refused",
+		},
+	}
+	app := &handlersfakes.FakeAppGetBots{}
+	app.GetBotsReturns(bots)
+
+	r := chi.NewRouter()
+	r.Method("GET", "/{"+handlers.URLParamGame+"}", handlers.NewGetBotsHandler(app))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	t.Run("bots", func(t *testing.T) {
+		req, err := http.NewRequest("GET", server.URL+"/3", nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "application/json")
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var actual *models.GameBots
+		err = json.NewDecoder(resp.Body).Decode(&actual)
+		require.NoError(t, err)
+		require.Equal(t, &models.GameBots{
+			Game: 3,
+			Bots: bots,
+		}, actual)
+
+		_, gameId := app.GetBotsArgsForCall(0)
+		require.Equal(t, 3, gameId)
+	})
+
+	t.Run("invalid game", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "/abc")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
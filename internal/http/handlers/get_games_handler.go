@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . AppGetGames
+type AppGetGames interface {
+	GetGames(ctx context.Context) (*models.ServerGames, error)
+}
+
+type GetGamesHandler struct {
+	app AppGetGames
+}
+
+func NewGetGamesHandler(app AppGetGames) http.Handler {
+	return &GetGamesHandler{
+		app: app,
+	}
+}
+
+func (h *GetGamesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx = utils.WithModule(ctx, "get_games_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("get games handler started")
+
+	games, err := h.app.GetGames(ctx)
+	if err != nil {
+		log.WithError(err).Error("get games fail")
+		respondError(w, r, appGetGamesErrStatus(err))
+		return
+	}
+
+	respond(w, r, http.StatusOK, games)
+}
+
+func appGetGamesErrStatus(err error) int {
+	if errors.Is(err, core.ErrNoGamesClient) {
+		return http.StatusNotImplemented
+	}
+
+	return http.StatusBadGateway
+}
@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+func Test_GetGamesHandler(t *testing.T) {
+	expected := &models.ServerGames{
+		Games: []*models.ServerGame{
+			{Id: 1, Width: 40, Height: 30, Limit: 10, Count: 4, Bots: 3},
+		},
+	}
+	app := &handlersfakes.FakeAppGetGames{}
+	app.GetGamesReturns(expected, nil)
+
+	server := httptest.NewServer(handlers.NewGetGamesHandler(app))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var games *models.ServerGames
+	err = json.NewDecoder(resp.Body).Decode(&games)
+	require.NoError(t, err)
+	require.Equal(t, expected, games)
+}
+
+func Test_GetGamesHandler_Failure(t *testing.T) {
+	app := &handlersfakes.FakeAppGetGames{}
+	app.GetGamesReturns(nil, errors.New("connection refused"))
+
+	server := httptest.NewServer(handlers.NewGetGamesHandler(app))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
+
+func Test_GetGamesHandler_NoGamesClient(t *testing.T) {
+	app := &handlersfakes.FakeAppGetGames{}
+	app.GetGamesReturns(nil, core.ErrNoGamesClient)
+
+	server := httptest.NewServer(handlers.NewGetGamesHandler(app))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . AppGetRemovedGames
+type AppGetRemovedGames interface {
+	GetRemovedGames(ctx context.Context) *models.RemovedGames
+}
+
+type GetRemovedGamesHandler struct {
+	app AppGetRemovedGames
+}
+
+func NewGetRemovedGamesHandler(app AppGetRemovedGames) http.Handler {
+	return &GetRemovedGamesHandler{
+		app: app,
+	}
+}
+
+func (h *GetRemovedGamesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx = utils.WithModule(ctx, "get_removed_games_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("get removed games handler started")
+
+	respond(w, r, http.StatusOK, h.app.GetRemovedGames(ctx))
+}
@@ -0,0 +1,46 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+func Test_GetRemovedGamesHandler(t *testing.T) {
+	expected := &models.RemovedGames{
+		Games: []*models.RemovedGame{
+			{
+				Game:    7,
+				Bots:    3,
+				Removed: time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	app := &handlersfakes.FakeAppGetRemovedGames{}
+	app.GetRemovedGamesReturns(expected)
+
+	server := httptest.NewServer(handlers.NewGetRemovedGamesHandler(app))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var games *models.RemovedGames
+	err = json.NewDecoder(resp.Body).Decode(&games)
+	require.NoError(t, err)
+	require.Equal(t, expected, games)
+}
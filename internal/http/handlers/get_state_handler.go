@@ -11,6 +11,8 @@ import (
 //counterfeiter:generate . AppGetState
 type AppGetState interface {
 	GetState(ctx context.Context) map[int]int
+	GetSettings(ctx context.Context) map[int]*models.GameSettings
+	GetSeeds(ctx context.Context) map[int][]int64
 }
 
 type GetStateHandler struct {
@@ -31,7 +33,9 @@ func (h *GetStateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Info("get state handler started")
 
 	state := h.app.GetState(ctx)
-	data := models.NewGames(state)
+	settings := h.app.GetSettings(ctx)
+	seeds := h.app.GetSeeds(ctx)
+	data := models.NewGames(state).WithSettings(settings).WithSeeds(seeds)
 
 	respond(w, r, http.StatusOK, data)
 }
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -117,3 +118,40 @@ func Test_GetStateHandler_AcceptDeadbeef(t *testing.T) {
 
 	require.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
 }
+
+func Test_GetStateHandler_ProfilesAndSeeds(t *testing.T) {
+	app := &handlersfakes.FakeAppGetState{}
+	app.GetStateReturns(map[int]int{
+		1: 2,
+		3: 1,
+	})
+	app.GetSettingsReturns(map[int]*models.GameSettings{
+		3: {
+			Profiles:   []string{"pacifist"},
+			Discoverer: "astar",
+		},
+	})
+	app.GetSeedsReturns(map[int][]int64{
+		1: {11, -12},
+		3: {31},
+	})
+
+	expectBody := "games:\n" +
+		"- game: 1\n  bots: 2\n  seeds:\n  - 11\n  - -12\n" +
+		"- game: 3\n  bots: 1\n  profiles:\n  - pacifist\n  discoverer: astar\n" +
+		"  seeds:\n  - 31\n"
+
+	server := httptest.NewServer(handlers.NewGetStateHandler(app))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	defer resp.Body.Close()
+
+	require.Equal(t, 200, resp.StatusCode)
+
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, expectBody, string(content))
+}
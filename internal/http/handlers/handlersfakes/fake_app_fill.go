@@ -0,0 +1,189 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package handlersfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+type FakeAppFill struct {
+	GetFillPolicyStub        func(context.Context) *models.FillPolicy
+	getFillPolicyMutex       sync.RWMutex
+	getFillPolicyArgsForCall []struct {
+		arg1 context.Context
+	}
+	getFillPolicyReturns struct {
+		result1 *models.FillPolicy
+	}
+	getFillPolicyReturnsOnCall map[int]struct {
+		result1 *models.FillPolicy
+	}
+	SetFillPolicyStub        func(context.Context, *models.FillPolicy) error
+	setFillPolicyMutex       sync.RWMutex
+	setFillPolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.FillPolicy
+	}
+	setFillPolicyReturns struct {
+		result1 error
+	}
+	setFillPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAppFill) GetFillPolicy(arg1 context.Context) *models.FillPolicy {
+	fake.getFillPolicyMutex.Lock()
+	ret, specificReturn := fake.getFillPolicyReturnsOnCall[len(fake.getFillPolicyArgsForCall)]
+	fake.getFillPolicyArgsForCall = append(fake.getFillPolicyArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetFillPolicyStub
+	fakeReturns := fake.getFillPolicyReturns
+	fake.recordInvocation("GetFillPolicy", []interface{}{arg1})
+	fake.getFillPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAppFill) GetFillPolicyCallCount() int {
+	fake.getFillPolicyMutex.RLock()
+	defer fake.getFillPolicyMutex.RUnlock()
+	return len(fake.getFillPolicyArgsForCall)
+}
+
+func (fake *FakeAppFill) GetFillPolicyCalls(stub func(context.Context) *models.FillPolicy) {
+	fake.getFillPolicyMutex.Lock()
+	defer fake.getFillPolicyMutex.Unlock()
+	fake.GetFillPolicyStub = stub
+}
+
+func (fake *FakeAppFill) GetFillPolicyArgsForCall(i int) context.Context {
+	fake.getFillPolicyMutex.RLock()
+	defer fake.getFillPolicyMutex.RUnlock()
+	argsForCall := fake.getFillPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAppFill) GetFillPolicyReturns(result1 *models.FillPolicy) {
+	fake.getFillPolicyMutex.Lock()
+	defer fake.getFillPolicyMutex.Unlock()
+	fake.GetFillPolicyStub = nil
+	fake.getFillPolicyReturns = struct {
+		result1 *models.FillPolicy
+	}{result1}
+}
+
+func (fake *FakeAppFill) GetFillPolicyReturnsOnCall(i int, result1 *models.FillPolicy) {
+	fake.getFillPolicyMutex.Lock()
+	defer fake.getFillPolicyMutex.Unlock()
+	fake.GetFillPolicyStub = nil
+	if fake.getFillPolicyReturnsOnCall == nil {
+		fake.getFillPolicyReturnsOnCall = make(map[int]struct {
+			result1 *models.FillPolicy
+		})
+	}
+	fake.getFillPolicyReturnsOnCall[i] = struct {
+		result1 *models.FillPolicy
+	}{result1}
+}
+
+func (fake *FakeAppFill) SetFillPolicy(arg1 context.Context, arg2 *models.FillPolicy) error {
+	fake.setFillPolicyMutex.Lock()
+	ret, specificReturn := fake.setFillPolicyReturnsOnCall[len(fake.setFillPolicyArgsForCall)]
+	fake.setFillPolicyArgsForCall = append(fake.setFillPolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.FillPolicy
+	}{arg1, arg2})
+	stub := fake.SetFillPolicyStub
+	fakeReturns := fake.setFillPolicyReturns
+	fake.recordInvocation("SetFillPolicy", []interface{}{arg1, arg2})
+	fake.setFillPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAppFill) SetFillPolicyCallCount() int {
+	fake.setFillPolicyMutex.RLock()
+	defer fake.setFillPolicyMutex.RUnlock()
+	return len(fake.setFillPolicyArgsForCall)
+}
+
+func (fake *FakeAppFill) SetFillPolicyCalls(stub func(context.Context, *models.FillPolicy) error) {
+	fake.setFillPolicyMutex.Lock()
+	defer fake.setFillPolicyMutex.Unlock()
+	fake.SetFillPolicyStub = stub
+}
+
+func (fake *FakeAppFill) SetFillPolicyArgsForCall(i int) (context.Context, *models.FillPolicy) {
+	fake.setFillPolicyMutex.RLock()
+	defer fake.setFillPolicyMutex.RUnlock()
+	argsForCall := fake.setFillPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAppFill) SetFillPolicyReturns(result1 error) {
+	fake.setFillPolicyMutex.Lock()
+	defer fake.setFillPolicyMutex.Unlock()
+	fake.SetFillPolicyStub = nil
+	fake.setFillPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAppFill) SetFillPolicyReturnsOnCall(i int, result1 error) {
+	fake.setFillPolicyMutex.Lock()
+	defer fake.setFillPolicyMutex.Unlock()
+	fake.SetFillPolicyStub = nil
+	if fake.setFillPolicyReturnsOnCall == nil {
+		fake.setFillPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setFillPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAppFill) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getFillPolicyMutex.RLock()
+	defer fake.getFillPolicyMutex.RUnlock()
+	fake.setFillPolicyMutex.RLock()
+	defer fake.setFillPolicyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAppFill) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AppFill = new(FakeAppFill)
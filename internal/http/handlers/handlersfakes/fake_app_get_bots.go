@@ -0,0 +1,115 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package handlersfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+type FakeAppGetBots struct {
+	GetBotsStub        func(context.Context, int) []*models.BotStatus
+	getBotsMutex       sync.RWMutex
+	getBotsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	getBotsReturns struct {
+		result1 []*models.BotStatus
+	}
+	getBotsReturnsOnCall map[int]struct {
+		result1 []*models.BotStatus
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAppGetBots) GetBots(arg1 context.Context, arg2 int) []*models.BotStatus {
+	fake.getBotsMutex.Lock()
+	ret, specificReturn := fake.getBotsReturnsOnCall[len(fake.getBotsArgsForCall)]
+	fake.getBotsArgsForCall = append(fake.getBotsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetBotsStub
+	fakeReturns := fake.getBotsReturns
+	fake.recordInvocation("GetBots", []interface{}{arg1, arg2})
+	fake.getBotsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAppGetBots) GetBotsCallCount() int {
+	fake.getBotsMutex.RLock()
+	defer fake.getBotsMutex.RUnlock()
+	return len(fake.getBotsArgsForCall)
+}
+
+func (fake *FakeAppGetBots) GetBotsCalls(stub func(context.Context, int) []*models.BotStatus) {
+	fake.getBotsMutex.Lock()
+	defer fake.getBotsMutex.Unlock()
+	fake.GetBotsStub = stub
+}
+
+func (fake *FakeAppGetBots) GetBotsArgsForCall(i int) (context.Context, int) {
+	fake.getBotsMutex.RLock()
+	defer fake.getBotsMutex.RUnlock()
+	argsForCall := fake.getBotsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAppGetBots) GetBotsReturns(result1 []*models.BotStatus) {
+	fake.getBotsMutex.Lock()
+	defer fake.getBotsMutex.Unlock()
+	fake.GetBotsStub = nil
+	fake.getBotsReturns = struct {
+		result1 []*models.BotStatus
+	}{result1}
+}
+
+func (fake *FakeAppGetBots) GetBotsReturnsOnCall(i int, result1 []*models.BotStatus) {
+	fake.getBotsMutex.Lock()
+	defer fake.getBotsMutex.Unlock()
+	fake.GetBotsStub = nil
+	if fake.getBotsReturnsOnCall == nil {
+		fake.getBotsReturnsOnCall = make(map[int]struct {
+			result1 []*models.BotStatus
+		})
+	}
+	fake.getBotsReturnsOnCall[i] = struct {
+		result1 []*models.BotStatus
+	}{result1}
+}
+
+func (fake *FakeAppGetBots) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getBotsMutex.RLock()
+	defer fake.getBotsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAppGetBots) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AppGetBots = new(FakeAppGetBots)
@@ -0,0 +1,118 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package handlersfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+type FakeAppGetGames struct {
+	GetGamesStub        func(context.Context) (*models.ServerGames, error)
+	getGamesMutex       sync.RWMutex
+	getGamesArgsForCall []struct {
+		arg1 context.Context
+	}
+	getGamesReturns struct {
+		result1 *models.ServerGames
+		result2 error
+	}
+	getGamesReturnsOnCall map[int]struct {
+		result1 *models.ServerGames
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAppGetGames) GetGames(arg1 context.Context) (*models.ServerGames, error) {
+	fake.getGamesMutex.Lock()
+	ret, specificReturn := fake.getGamesReturnsOnCall[len(fake.getGamesArgsForCall)]
+	fake.getGamesArgsForCall = append(fake.getGamesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetGamesStub
+	fakeReturns := fake.getGamesReturns
+	fake.recordInvocation("GetGames", []interface{}{arg1})
+	fake.getGamesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAppGetGames) GetGamesCallCount() int {
+	fake.getGamesMutex.RLock()
+	defer fake.getGamesMutex.RUnlock()
+	return len(fake.getGamesArgsForCall)
+}
+
+func (fake *FakeAppGetGames) GetGamesCalls(stub func(context.Context) (*models.ServerGames, error)) {
+	fake.getGamesMutex.Lock()
+	defer fake.getGamesMutex.Unlock()
+	fake.GetGamesStub = stub
+}
+
+func (fake *FakeAppGetGames) GetGamesArgsForCall(i int) context.Context {
+	fake.getGamesMutex.RLock()
+	defer fake.getGamesMutex.RUnlock()
+	argsForCall := fake.getGamesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAppGetGames) GetGamesReturns(result1 *models.ServerGames, result2 error) {
+	fake.getGamesMutex.Lock()
+	defer fake.getGamesMutex.Unlock()
+	fake.GetGamesStub = nil
+	fake.getGamesReturns = struct {
+		result1 *models.ServerGames
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppGetGames) GetGamesReturnsOnCall(i int, result1 *models.ServerGames, result2 error) {
+	fake.getGamesMutex.Lock()
+	defer fake.getGamesMutex.Unlock()
+	fake.GetGamesStub = nil
+	if fake.getGamesReturnsOnCall == nil {
+		fake.getGamesReturnsOnCall = make(map[int]struct {
+			result1 *models.ServerGames
+			result2 error
+		})
+	}
+	fake.getGamesReturnsOnCall[i] = struct {
+		result1 *models.ServerGames
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppGetGames) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getGamesMutex.RLock()
+	defer fake.getGamesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAppGetGames) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AppGetGames = new(FakeAppGetGames)
@@ -0,0 +1,113 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package handlersfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+type FakeAppGetRemovedGames struct {
+	GetRemovedGamesStub        func(context.Context) *models.RemovedGames
+	getRemovedGamesMutex       sync.RWMutex
+	getRemovedGamesArgsForCall []struct {
+		arg1 context.Context
+	}
+	getRemovedGamesReturns struct {
+		result1 *models.RemovedGames
+	}
+	getRemovedGamesReturnsOnCall map[int]struct {
+		result1 *models.RemovedGames
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAppGetRemovedGames) GetRemovedGames(arg1 context.Context) *models.RemovedGames {
+	fake.getRemovedGamesMutex.Lock()
+	ret, specificReturn := fake.getRemovedGamesReturnsOnCall[len(fake.getRemovedGamesArgsForCall)]
+	fake.getRemovedGamesArgsForCall = append(fake.getRemovedGamesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetRemovedGamesStub
+	fakeReturns := fake.getRemovedGamesReturns
+	fake.recordInvocation("GetRemovedGames", []interface{}{arg1})
+	fake.getRemovedGamesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAppGetRemovedGames) GetRemovedGamesCallCount() int {
+	fake.getRemovedGamesMutex.RLock()
+	defer fake.getRemovedGamesMutex.RUnlock()
+	return len(fake.getRemovedGamesArgsForCall)
+}
+
+func (fake *FakeAppGetRemovedGames) GetRemovedGamesCalls(stub func(context.Context) *models.RemovedGames) {
+	fake.getRemovedGamesMutex.Lock()
+	defer fake.getRemovedGamesMutex.Unlock()
+	fake.GetRemovedGamesStub = stub
+}
+
+func (fake *FakeAppGetRemovedGames) GetRemovedGamesArgsForCall(i int) context.Context {
+	fake.getRemovedGamesMutex.RLock()
+	defer fake.getRemovedGamesMutex.RUnlock()
+	argsForCall := fake.getRemovedGamesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAppGetRemovedGames) GetRemovedGamesReturns(result1 *models.RemovedGames) {
+	fake.getRemovedGamesMutex.Lock()
+	defer fake.getRemovedGamesMutex.Unlock()
+	fake.GetRemovedGamesStub = nil
+	fake.getRemovedGamesReturns = struct {
+		result1 *models.RemovedGames
+	}{result1}
+}
+
+func (fake *FakeAppGetRemovedGames) GetRemovedGamesReturnsOnCall(i int, result1 *models.RemovedGames) {
+	fake.getRemovedGamesMutex.Lock()
+	defer fake.getRemovedGamesMutex.Unlock()
+	fake.GetRemovedGamesStub = nil
+	if fake.getRemovedGamesReturnsOnCall == nil {
+		fake.getRemovedGamesReturnsOnCall = make(map[int]struct {
+			result1 *models.RemovedGames
+		})
+	}
+	fake.getRemovedGamesReturnsOnCall[i] = struct {
+		result1 *models.RemovedGames
+	}{result1}
+}
+
+func (fake *FakeAppGetRemovedGames) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getRemovedGamesMutex.RLock()
+	defer fake.getRemovedGamesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAppGetRemovedGames) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AppGetRemovedGames = new(FakeAppGetRemovedGames)
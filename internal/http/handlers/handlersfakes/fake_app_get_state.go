@@ -6,9 +6,32 @@ import (
 	"sync"
 
 	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 )
 
 type FakeAppGetState struct {
+	GetSeedsStub        func(context.Context) map[int][]int64
+	getSeedsMutex       sync.RWMutex
+	getSeedsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getSeedsReturns struct {
+		result1 map[int][]int64
+	}
+	getSeedsReturnsOnCall map[int]struct {
+		result1 map[int][]int64
+	}
+	GetSettingsStub        func(context.Context) map[int]*models.GameSettings
+	getSettingsMutex       sync.RWMutex
+	getSettingsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getSettingsReturns struct {
+		result1 map[int]*models.GameSettings
+	}
+	getSettingsReturnsOnCall map[int]struct {
+		result1 map[int]*models.GameSettings
+	}
 	GetStateStub        func(context.Context) map[int]int
 	getStateMutex       sync.RWMutex
 	getStateArgsForCall []struct {
@@ -24,6 +47,128 @@ type FakeAppGetState struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeAppGetState) GetSeeds(arg1 context.Context) map[int][]int64 {
+	fake.getSeedsMutex.Lock()
+	ret, specificReturn := fake.getSeedsReturnsOnCall[len(fake.getSeedsArgsForCall)]
+	fake.getSeedsArgsForCall = append(fake.getSeedsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetSeedsStub
+	fakeReturns := fake.getSeedsReturns
+	fake.recordInvocation("GetSeeds", []interface{}{arg1})
+	fake.getSeedsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAppGetState) GetSeedsCallCount() int {
+	fake.getSeedsMutex.RLock()
+	defer fake.getSeedsMutex.RUnlock()
+	return len(fake.getSeedsArgsForCall)
+}
+
+func (fake *FakeAppGetState) GetSeedsCalls(stub func(context.Context) map[int][]int64) {
+	fake.getSeedsMutex.Lock()
+	defer fake.getSeedsMutex.Unlock()
+	fake.GetSeedsStub = stub
+}
+
+func (fake *FakeAppGetState) GetSeedsArgsForCall(i int) context.Context {
+	fake.getSeedsMutex.RLock()
+	defer fake.getSeedsMutex.RUnlock()
+	argsForCall := fake.getSeedsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAppGetState) GetSeedsReturns(result1 map[int][]int64) {
+	fake.getSeedsMutex.Lock()
+	defer fake.getSeedsMutex.Unlock()
+	fake.GetSeedsStub = nil
+	fake.getSeedsReturns = struct {
+		result1 map[int][]int64
+	}{result1}
+}
+
+func (fake *FakeAppGetState) GetSeedsReturnsOnCall(i int, result1 map[int][]int64) {
+	fake.getSeedsMutex.Lock()
+	defer fake.getSeedsMutex.Unlock()
+	fake.GetSeedsStub = nil
+	if fake.getSeedsReturnsOnCall == nil {
+		fake.getSeedsReturnsOnCall = make(map[int]struct {
+			result1 map[int][]int64
+		})
+	}
+	fake.getSeedsReturnsOnCall[i] = struct {
+		result1 map[int][]int64
+	}{result1}
+}
+
+func (fake *FakeAppGetState) GetSettings(arg1 context.Context) map[int]*models.GameSettings {
+	fake.getSettingsMutex.Lock()
+	ret, specificReturn := fake.getSettingsReturnsOnCall[len(fake.getSettingsArgsForCall)]
+	fake.getSettingsArgsForCall = append(fake.getSettingsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetSettingsStub
+	fakeReturns := fake.getSettingsReturns
+	fake.recordInvocation("GetSettings", []interface{}{arg1})
+	fake.getSettingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAppGetState) GetSettingsCallCount() int {
+	fake.getSettingsMutex.RLock()
+	defer fake.getSettingsMutex.RUnlock()
+	return len(fake.getSettingsArgsForCall)
+}
+
+func (fake *FakeAppGetState) GetSettingsCalls(stub func(context.Context) map[int]*models.GameSettings) {
+	fake.getSettingsMutex.Lock()
+	defer fake.getSettingsMutex.Unlock()
+	fake.GetSettingsStub = stub
+}
+
+func (fake *FakeAppGetState) GetSettingsArgsForCall(i int) context.Context {
+	fake.getSettingsMutex.RLock()
+	defer fake.getSettingsMutex.RUnlock()
+	argsForCall := fake.getSettingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAppGetState) GetSettingsReturns(result1 map[int]*models.GameSettings) {
+	fake.getSettingsMutex.Lock()
+	defer fake.getSettingsMutex.Unlock()
+	fake.GetSettingsStub = nil
+	fake.getSettingsReturns = struct {
+		result1 map[int]*models.GameSettings
+	}{result1}
+}
+
+func (fake *FakeAppGetState) GetSettingsReturnsOnCall(i int, result1 map[int]*models.GameSettings) {
+	fake.getSettingsMutex.Lock()
+	defer fake.getSettingsMutex.Unlock()
+	fake.GetSettingsStub = nil
+	if fake.getSettingsReturnsOnCall == nil {
+		fake.getSettingsReturnsOnCall = make(map[int]struct {
+			result1 map[int]*models.GameSettings
+		})
+	}
+	fake.getSettingsReturnsOnCall[i] = struct {
+		result1 map[int]*models.GameSettings
+	}{result1}
+}
+
 func (fake *FakeAppGetState) GetState(arg1 context.Context) map[int]int {
 	fake.getStateMutex.Lock()
 	ret, specificReturn := fake.getStateReturnsOnCall[len(fake.getStateArgsForCall)]
@@ -88,6 +233,10 @@ func (fake *FakeAppGetState) GetStateReturnsOnCall(i int, result1 map[int]int) {
 func (fake *FakeAppGetState) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getSeedsMutex.RLock()
+	defer fake.getSeedsMutex.RUnlock()
+	fake.getSettingsMutex.RLock()
+	defer fake.getSettingsMutex.RUnlock()
 	fake.getStateMutex.RLock()
 	defer fake.getStateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
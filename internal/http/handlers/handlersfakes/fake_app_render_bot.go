@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package handlersfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+)
+
+type FakeAppRenderBot struct {
+	RenderBotStub        func(context.Context, int, int) ([]byte, error)
+	renderBotMutex       sync.RWMutex
+	renderBotArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}
+	renderBotReturns struct {
+		result1 []byte
+		result2 error
+	}
+	renderBotReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAppRenderBot) RenderBot(arg1 context.Context, arg2 int, arg3 int) ([]byte, error) {
+	fake.renderBotMutex.Lock()
+	ret, specificReturn := fake.renderBotReturnsOnCall[len(fake.renderBotArgsForCall)]
+	fake.renderBotArgsForCall = append(fake.renderBotArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.RenderBotStub
+	fakeReturns := fake.renderBotReturns
+	fake.recordInvocation("RenderBot", []interface{}{arg1, arg2, arg3})
+	fake.renderBotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAppRenderBot) RenderBotCallCount() int {
+	fake.renderBotMutex.RLock()
+	defer fake.renderBotMutex.RUnlock()
+	return len(fake.renderBotArgsForCall)
+}
+
+func (fake *FakeAppRenderBot) RenderBotCalls(stub func(context.Context, int, int) ([]byte, error)) {
+	fake.renderBotMutex.Lock()
+	defer fake.renderBotMutex.Unlock()
+	fake.RenderBotStub = stub
+}
+
+func (fake *FakeAppRenderBot) RenderBotArgsForCall(i int) (context.Context, int, int) {
+	fake.renderBotMutex.RLock()
+	defer fake.renderBotMutex.RUnlock()
+	argsForCall := fake.renderBotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAppRenderBot) RenderBotReturns(result1 []byte, result2 error) {
+	fake.renderBotMutex.Lock()
+	defer fake.renderBotMutex.Unlock()
+	fake.RenderBotStub = nil
+	fake.renderBotReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppRenderBot) RenderBotReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.renderBotMutex.Lock()
+	defer fake.renderBotMutex.Unlock()
+	fake.RenderBotStub = nil
+	if fake.renderBotReturnsOnCall == nil {
+		fake.renderBotReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.renderBotReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppRenderBot) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.renderBotMutex.RLock()
+	defer fake.renderBotMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAppRenderBot) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AppRenderBot = new(FakeAppRenderBot)
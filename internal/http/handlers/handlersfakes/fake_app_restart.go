@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package handlersfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+)
+
+type FakeAppRestart struct {
+	RestartStub        func(context.Context, int) (map[int]int, error)
+	restartMutex       sync.RWMutex
+	restartArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	restartReturns struct {
+		result1 map[int]int
+		result2 error
+	}
+	restartReturnsOnCall map[int]struct {
+		result1 map[int]int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAppRestart) Restart(arg1 context.Context, arg2 int) (map[int]int, error) {
+	fake.restartMutex.Lock()
+	ret, specificReturn := fake.restartReturnsOnCall[len(fake.restartArgsForCall)]
+	fake.restartArgsForCall = append(fake.restartArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RestartStub
+	fakeReturns := fake.restartReturns
+	fake.recordInvocation("Restart", []interface{}{arg1, arg2})
+	fake.restartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAppRestart) RestartCallCount() int {
+	fake.restartMutex.RLock()
+	defer fake.restartMutex.RUnlock()
+	return len(fake.restartArgsForCall)
+}
+
+func (fake *FakeAppRestart) RestartCalls(stub func(context.Context, int) (map[int]int, error)) {
+	fake.restartMutex.Lock()
+	defer fake.restartMutex.Unlock()
+	fake.RestartStub = stub
+}
+
+func (fake *FakeAppRestart) RestartArgsForCall(i int) (context.Context, int) {
+	fake.restartMutex.RLock()
+	defer fake.restartMutex.RUnlock()
+	argsForCall := fake.restartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAppRestart) RestartReturns(result1 map[int]int, result2 error) {
+	fake.restartMutex.Lock()
+	defer fake.restartMutex.Unlock()
+	fake.RestartStub = nil
+	fake.restartReturns = struct {
+		result1 map[int]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppRestart) RestartReturnsOnCall(i int, result1 map[int]int, result2 error) {
+	fake.restartMutex.Lock()
+	defer fake.restartMutex.Unlock()
+	fake.RestartStub = nil
+	if fake.restartReturnsOnCall == nil {
+		fake.restartReturnsOnCall = make(map[int]struct {
+			result1 map[int]int
+			result2 error
+		})
+	}
+	fake.restartReturnsOnCall[i] = struct {
+		result1 map[int]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppRestart) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.restartMutex.RLock()
+	defer fake.restartMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAppRestart) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AppRestart = new(FakeAppRestart)
@@ -6,15 +6,54 @@ import (
 	"sync"
 
 	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 )
 
 type FakeAppSetState struct {
-	SetOneStub        func(context.Context, int, int) (map[int]int, error)
+	GetSeedsStub        func(context.Context) map[int][]int64
+	getSeedsMutex       sync.RWMutex
+	getSeedsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getSeedsReturns struct {
+		result1 map[int][]int64
+	}
+	getSeedsReturnsOnCall map[int]struct {
+		result1 map[int][]int64
+	}
+	GetSettingsStub        func(context.Context) map[int]*models.GameSettings
+	getSettingsMutex       sync.RWMutex
+	getSettingsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getSettingsReturns struct {
+		result1 map[int]*models.GameSettings
+	}
+	getSettingsReturnsOnCall map[int]struct {
+		result1 map[int]*models.GameSettings
+	}
+	SetGamesStub        func(context.Context, map[int]int, map[int]*models.GameSettings) (map[int]int, error)
+	setGamesMutex       sync.RWMutex
+	setGamesArgsForCall []struct {
+		arg1 context.Context
+		arg2 map[int]int
+		arg3 map[int]*models.GameSettings
+	}
+	setGamesReturns struct {
+		result1 map[int]int
+		result2 error
+	}
+	setGamesReturnsOnCall map[int]struct {
+		result1 map[int]int
+		result2 error
+	}
+	SetOneStub        func(context.Context, int, int, *models.GameSettings) (map[int]int, error)
 	setOneMutex       sync.RWMutex
 	setOneArgsForCall []struct {
 		arg1 context.Context
 		arg2 int
 		arg3 int
+		arg4 *models.GameSettings
 	}
 	setOneReturns struct {
 		result1 map[int]int
@@ -24,38 +63,213 @@ type FakeAppSetState struct {
 		result1 map[int]int
 		result2 error
 	}
-	SetStateStub        func(context.Context, map[int]int) (map[int]int, error)
-	setStateMutex       sync.RWMutex
-	setStateArgsForCall []struct {
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAppSetState) GetSeeds(arg1 context.Context) map[int][]int64 {
+	fake.getSeedsMutex.Lock()
+	ret, specificReturn := fake.getSeedsReturnsOnCall[len(fake.getSeedsArgsForCall)]
+	fake.getSeedsArgsForCall = append(fake.getSeedsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetSeedsStub
+	fakeReturns := fake.getSeedsReturns
+	fake.recordInvocation("GetSeeds", []interface{}{arg1})
+	fake.getSeedsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAppSetState) GetSeedsCallCount() int {
+	fake.getSeedsMutex.RLock()
+	defer fake.getSeedsMutex.RUnlock()
+	return len(fake.getSeedsArgsForCall)
+}
+
+func (fake *FakeAppSetState) GetSeedsCalls(stub func(context.Context) map[int][]int64) {
+	fake.getSeedsMutex.Lock()
+	defer fake.getSeedsMutex.Unlock()
+	fake.GetSeedsStub = stub
+}
+
+func (fake *FakeAppSetState) GetSeedsArgsForCall(i int) context.Context {
+	fake.getSeedsMutex.RLock()
+	defer fake.getSeedsMutex.RUnlock()
+	argsForCall := fake.getSeedsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAppSetState) GetSeedsReturns(result1 map[int][]int64) {
+	fake.getSeedsMutex.Lock()
+	defer fake.getSeedsMutex.Unlock()
+	fake.GetSeedsStub = nil
+	fake.getSeedsReturns = struct {
+		result1 map[int][]int64
+	}{result1}
+}
+
+func (fake *FakeAppSetState) GetSeedsReturnsOnCall(i int, result1 map[int][]int64) {
+	fake.getSeedsMutex.Lock()
+	defer fake.getSeedsMutex.Unlock()
+	fake.GetSeedsStub = nil
+	if fake.getSeedsReturnsOnCall == nil {
+		fake.getSeedsReturnsOnCall = make(map[int]struct {
+			result1 map[int][]int64
+		})
+	}
+	fake.getSeedsReturnsOnCall[i] = struct {
+		result1 map[int][]int64
+	}{result1}
+}
+
+func (fake *FakeAppSetState) GetSettings(arg1 context.Context) map[int]*models.GameSettings {
+	fake.getSettingsMutex.Lock()
+	ret, specificReturn := fake.getSettingsReturnsOnCall[len(fake.getSettingsArgsForCall)]
+	fake.getSettingsArgsForCall = append(fake.getSettingsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetSettingsStub
+	fakeReturns := fake.getSettingsReturns
+	fake.recordInvocation("GetSettings", []interface{}{arg1})
+	fake.getSettingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAppSetState) GetSettingsCallCount() int {
+	fake.getSettingsMutex.RLock()
+	defer fake.getSettingsMutex.RUnlock()
+	return len(fake.getSettingsArgsForCall)
+}
+
+func (fake *FakeAppSetState) GetSettingsCalls(stub func(context.Context) map[int]*models.GameSettings) {
+	fake.getSettingsMutex.Lock()
+	defer fake.getSettingsMutex.Unlock()
+	fake.GetSettingsStub = stub
+}
+
+func (fake *FakeAppSetState) GetSettingsArgsForCall(i int) context.Context {
+	fake.getSettingsMutex.RLock()
+	defer fake.getSettingsMutex.RUnlock()
+	argsForCall := fake.getSettingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAppSetState) GetSettingsReturns(result1 map[int]*models.GameSettings) {
+	fake.getSettingsMutex.Lock()
+	defer fake.getSettingsMutex.Unlock()
+	fake.GetSettingsStub = nil
+	fake.getSettingsReturns = struct {
+		result1 map[int]*models.GameSettings
+	}{result1}
+}
+
+func (fake *FakeAppSetState) GetSettingsReturnsOnCall(i int, result1 map[int]*models.GameSettings) {
+	fake.getSettingsMutex.Lock()
+	defer fake.getSettingsMutex.Unlock()
+	fake.GetSettingsStub = nil
+	if fake.getSettingsReturnsOnCall == nil {
+		fake.getSettingsReturnsOnCall = make(map[int]struct {
+			result1 map[int]*models.GameSettings
+		})
+	}
+	fake.getSettingsReturnsOnCall[i] = struct {
+		result1 map[int]*models.GameSettings
+	}{result1}
+}
+
+func (fake *FakeAppSetState) SetGames(arg1 context.Context, arg2 map[int]int, arg3 map[int]*models.GameSettings) (map[int]int, error) {
+	fake.setGamesMutex.Lock()
+	ret, specificReturn := fake.setGamesReturnsOnCall[len(fake.setGamesArgsForCall)]
+	fake.setGamesArgsForCall = append(fake.setGamesArgsForCall, struct {
 		arg1 context.Context
 		arg2 map[int]int
+		arg3 map[int]*models.GameSettings
+	}{arg1, arg2, arg3})
+	stub := fake.SetGamesStub
+	fakeReturns := fake.setGamesReturns
+	fake.recordInvocation("SetGames", []interface{}{arg1, arg2, arg3})
+	fake.setGamesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
 	}
-	setStateReturns struct {
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAppSetState) SetGamesCallCount() int {
+	fake.setGamesMutex.RLock()
+	defer fake.setGamesMutex.RUnlock()
+	return len(fake.setGamesArgsForCall)
+}
+
+func (fake *FakeAppSetState) SetGamesCalls(stub func(context.Context, map[int]int, map[int]*models.GameSettings) (map[int]int, error)) {
+	fake.setGamesMutex.Lock()
+	defer fake.setGamesMutex.Unlock()
+	fake.SetGamesStub = stub
+}
+
+func (fake *FakeAppSetState) SetGamesArgsForCall(i int) (context.Context, map[int]int, map[int]*models.GameSettings) {
+	fake.setGamesMutex.RLock()
+	defer fake.setGamesMutex.RUnlock()
+	argsForCall := fake.setGamesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAppSetState) SetGamesReturns(result1 map[int]int, result2 error) {
+	fake.setGamesMutex.Lock()
+	defer fake.setGamesMutex.Unlock()
+	fake.SetGamesStub = nil
+	fake.setGamesReturns = struct {
 		result1 map[int]int
 		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppSetState) SetGamesReturnsOnCall(i int, result1 map[int]int, result2 error) {
+	fake.setGamesMutex.Lock()
+	defer fake.setGamesMutex.Unlock()
+	fake.SetGamesStub = nil
+	if fake.setGamesReturnsOnCall == nil {
+		fake.setGamesReturnsOnCall = make(map[int]struct {
+			result1 map[int]int
+			result2 error
+		})
 	}
-	setStateReturnsOnCall map[int]struct {
+	fake.setGamesReturnsOnCall[i] = struct {
 		result1 map[int]int
 		result2 error
-	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	}{result1, result2}
 }
 
-func (fake *FakeAppSetState) SetOne(arg1 context.Context, arg2 int, arg3 int) (map[int]int, error) {
+func (fake *FakeAppSetState) SetOne(arg1 context.Context, arg2 int, arg3 int, arg4 *models.GameSettings) (map[int]int, error) {
 	fake.setOneMutex.Lock()
 	ret, specificReturn := fake.setOneReturnsOnCall[len(fake.setOneArgsForCall)]
 	fake.setOneArgsForCall = append(fake.setOneArgsForCall, struct {
 		arg1 context.Context
 		arg2 int
 		arg3 int
-	}{arg1, arg2, arg3})
+		arg4 *models.GameSettings
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.SetOneStub
 	fakeReturns := fake.setOneReturns
-	fake.recordInvocation("SetOne", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("SetOne", []interface{}{arg1, arg2, arg3, arg4})
 	fake.setOneMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -69,17 +283,17 @@ func (fake *FakeAppSetState) SetOneCallCount() int {
 	return len(fake.setOneArgsForCall)
 }
 
-func (fake *FakeAppSetState) SetOneCalls(stub func(context.Context, int, int) (map[int]int, error)) {
+func (fake *FakeAppSetState) SetOneCalls(stub func(context.Context, int, int, *models.GameSettings) (map[int]int, error)) {
 	fake.setOneMutex.Lock()
 	defer fake.setOneMutex.Unlock()
 	fake.SetOneStub = stub
 }
 
-func (fake *FakeAppSetState) SetOneArgsForCall(i int) (context.Context, int, int) {
+func (fake *FakeAppSetState) SetOneArgsForCall(i int) (context.Context, int, int, *models.GameSettings) {
 	fake.setOneMutex.RLock()
 	defer fake.setOneMutex.RUnlock()
 	argsForCall := fake.setOneArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeAppSetState) SetOneReturns(result1 map[int]int, result2 error) {
@@ -108,78 +322,17 @@ func (fake *FakeAppSetState) SetOneReturnsOnCall(i int, result1 map[int]int, res
 	}{result1, result2}
 }
 
-func (fake *FakeAppSetState) SetState(arg1 context.Context, arg2 map[int]int) (map[int]int, error) {
-	fake.setStateMutex.Lock()
-	ret, specificReturn := fake.setStateReturnsOnCall[len(fake.setStateArgsForCall)]
-	fake.setStateArgsForCall = append(fake.setStateArgsForCall, struct {
-		arg1 context.Context
-		arg2 map[int]int
-	}{arg1, arg2})
-	stub := fake.SetStateStub
-	fakeReturns := fake.setStateReturns
-	fake.recordInvocation("SetState", []interface{}{arg1, arg2})
-	fake.setStateMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fakeReturns.result1, fakeReturns.result2
-}
-
-func (fake *FakeAppSetState) SetStateCallCount() int {
-	fake.setStateMutex.RLock()
-	defer fake.setStateMutex.RUnlock()
-	return len(fake.setStateArgsForCall)
-}
-
-func (fake *FakeAppSetState) SetStateCalls(stub func(context.Context, map[int]int) (map[int]int, error)) {
-	fake.setStateMutex.Lock()
-	defer fake.setStateMutex.Unlock()
-	fake.SetStateStub = stub
-}
-
-func (fake *FakeAppSetState) SetStateArgsForCall(i int) (context.Context, map[int]int) {
-	fake.setStateMutex.RLock()
-	defer fake.setStateMutex.RUnlock()
-	argsForCall := fake.setStateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
-}
-
-func (fake *FakeAppSetState) SetStateReturns(result1 map[int]int, result2 error) {
-	fake.setStateMutex.Lock()
-	defer fake.setStateMutex.Unlock()
-	fake.SetStateStub = nil
-	fake.setStateReturns = struct {
-		result1 map[int]int
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeAppSetState) SetStateReturnsOnCall(i int, result1 map[int]int, result2 error) {
-	fake.setStateMutex.Lock()
-	defer fake.setStateMutex.Unlock()
-	fake.SetStateStub = nil
-	if fake.setStateReturnsOnCall == nil {
-		fake.setStateReturnsOnCall = make(map[int]struct {
-			result1 map[int]int
-			result2 error
-		})
-	}
-	fake.setStateReturnsOnCall[i] = struct {
-		result1 map[int]int
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *FakeAppSetState) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getSeedsMutex.RLock()
+	defer fake.getSeedsMutex.RUnlock()
+	fake.getSettingsMutex.RLock()
+	defer fake.getSettingsMutex.RUnlock()
+	fake.setGamesMutex.RLock()
+	defer fake.setGamesMutex.RUnlock()
 	fake.setOneMutex.RLock()
 	defer fake.setOneMutex.RUnlock()
-	fake.setStateMutex.RLock()
-	defer fake.setStateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
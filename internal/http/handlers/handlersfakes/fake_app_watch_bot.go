@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package handlersfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+type FakeAppWatchBot struct {
+	WatchBotStub        func(context.Context, int, int) (<-chan *models.BotView, error)
+	watchBotMutex       sync.RWMutex
+	watchBotArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}
+	watchBotReturns struct {
+		result1 <-chan *models.BotView
+		result2 error
+	}
+	watchBotReturnsOnCall map[int]struct {
+		result1 <-chan *models.BotView
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAppWatchBot) WatchBot(arg1 context.Context, arg2 int, arg3 int) (<-chan *models.BotView, error) {
+	fake.watchBotMutex.Lock()
+	ret, specificReturn := fake.watchBotReturnsOnCall[len(fake.watchBotArgsForCall)]
+	fake.watchBotArgsForCall = append(fake.watchBotArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.WatchBotStub
+	fakeReturns := fake.watchBotReturns
+	fake.recordInvocation("WatchBot", []interface{}{arg1, arg2, arg3})
+	fake.watchBotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAppWatchBot) WatchBotCallCount() int {
+	fake.watchBotMutex.RLock()
+	defer fake.watchBotMutex.RUnlock()
+	return len(fake.watchBotArgsForCall)
+}
+
+func (fake *FakeAppWatchBot) WatchBotCalls(stub func(context.Context, int, int) (<-chan *models.BotView, error)) {
+	fake.watchBotMutex.Lock()
+	defer fake.watchBotMutex.Unlock()
+	fake.WatchBotStub = stub
+}
+
+func (fake *FakeAppWatchBot) WatchBotArgsForCall(i int) (context.Context, int, int) {
+	fake.watchBotMutex.RLock()
+	defer fake.watchBotMutex.RUnlock()
+	argsForCall := fake.watchBotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAppWatchBot) WatchBotReturns(result1 <-chan *models.BotView, result2 error) {
+	fake.watchBotMutex.Lock()
+	defer fake.watchBotMutex.Unlock()
+	fake.WatchBotStub = nil
+	fake.watchBotReturns = struct {
+		result1 <-chan *models.BotView
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppWatchBot) WatchBotReturnsOnCall(i int, result1 <-chan *models.BotView, result2 error) {
+	fake.watchBotMutex.Lock()
+	defer fake.watchBotMutex.Unlock()
+	fake.WatchBotStub = nil
+	if fake.watchBotReturnsOnCall == nil {
+		fake.watchBotReturnsOnCall = make(map[int]struct {
+			result1 <-chan *models.BotView
+			result2 error
+		})
+	}
+	fake.watchBotReturnsOnCall[i] = struct {
+		result1 <-chan *models.BotView
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppWatchBot) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.watchBotMutex.RLock()
+	defer fake.watchBotMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAppWatchBot) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AppWatchBot = new(FakeAppWatchBot)
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . AppRenderBot
+type AppRenderBot interface {
+	RenderBot(ctx context.Context, gameId, botId int) ([]byte, error)
+}
+
+type RenderBotHandler struct {
+	app AppRenderBot
+}
+
+// NewRenderBotHandler serves a PNG image of what a bot has seen and
+// decided on the last tick.
+func NewRenderBotHandler(app AppRenderBot) http.Handler {
+	return &RenderBotHandler{
+		app: app,
+	}
+}
+
+func (h *RenderBotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx = utils.WithModule(ctx, "render_bot_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("render bot handler started")
+
+	gameId, err := strconv.Atoi(chi.URLParam(r, URLParamGame))
+	if err != nil || gameId <= 0 {
+		log.WithError(err).Error("parse game id fail")
+		respondError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	botId, err := strconv.Atoi(chi.URLParam(r, URLParamBot))
+	if err != nil || botId <= 0 {
+		log.WithError(err).Error("parse bot id fail")
+		respondError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	image, err := h.app.RenderBot(ctx, gameId, botId)
+	if err != nil {
+		log.WithError(err).Error("render bot fail")
+		if errors.Is(err, core.ErrBotNotFound) ||
+			errors.Is(err, bot.ErrNotRendered) {
+			respondError(w, r, http.StatusNotFound)
+			return
+		}
+		respondError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Content-Length", strconv.Itoa(len(image)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(image); err != nil {
+		log.WithError(err).Error("write image fail")
+	}
+}
@@ -0,0 +1,74 @@
+package handlers_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+)
+
+func Test_RenderBotHandler(t *testing.T) {
+	image := []byte("\x89PNG")
+	app := &handlersfakes.FakeAppRenderBot{}
+
+	r := chi.NewRouter()
+	r.Method("GET", "/{"+handlers.URLParamGame+"}/{"+
+		handlers.URLParamBot+"}/view.png", handlers.NewRenderBotHandler(app))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	t.Run("image", func(t *testing.T) {
+		app.RenderBotReturns(image, nil)
+
+		resp, err := server.Client().Get(server.URL + "/3/2/view.png")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, image, body)
+
+		_, gameId, botId := app.RenderBotArgsForCall(0)
+		require.Equal(t, 3, gameId)
+		require.Equal(t, 2, botId)
+	})
+
+	t.Run("bot not found", func(t *testing.T) {
+		app.RenderBotReturns(nil, core.ErrBotNotFound)
+
+		resp, err := server.Client().Get(server.URL + "/3/2/view.png")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("not rendered", func(t *testing.T) {
+		app.RenderBotReturns(nil, errors.Wrap(bot.ErrNotRendered, "render bot"))
+
+		resp, err := server.Client().Get(server.URL + "/3/2/view.png")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("invalid game", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "/abc/2/view.png")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
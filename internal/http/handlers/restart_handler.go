@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . AppRestart
+type AppRestart interface {
+	Restart(ctx context.Context, gameId int) (map[int]int, error)
+}
+
+type RestartHandler struct {
+	app AppRestart
+}
+
+// NewRestartHandler replaces the bots with new ones one at a time.
+// The optional parameter game limits the restart to a game.
+func NewRestartHandler(app AppRestart) http.Handler {
+	return &RestartHandler{
+		app: app,
+	}
+}
+
+func (h *RestartHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ctx = utils.WithModule(ctx, "restart_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("restart handler started")
+
+	gameId := 0
+	if game := r.FormValue(URLParamGame); game != "" {
+		var err error
+		gameId, err = strconv.Atoi(game)
+		if err != nil || gameId <= 0 {
+			log.WithError(err).Error("parse game id fail")
+			respondError(w, r, http.StatusBadRequest)
+			return
+		}
+	}
+
+	state, err := h.app.Restart(ctx, gameId)
+	if err != nil {
+		log.WithError(err).Error("restart fail")
+		respondError(w, r, appRestartErrStatus(err))
+		return
+	}
+
+	respond(w, r, http.StatusAccepted, models.NewGames(state))
+}
+
+func appRestartErrStatus(err error) int {
+	if errors.Is(err, core.ErrRestarting) {
+		return http.StatusConflict
+	}
+
+	if errors.Is(err, core.ErrDraining) {
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+func Test_RestartHandler(t *testing.T) {
+	app := &handlersfakes.FakeAppRestart{}
+
+	server := httptest.NewServer(handlers.NewRestartHandler(app))
+	defer server.Close()
+
+	t.Run("all games", func(t *testing.T) {
+		app.RestartReturns(map[int]int{1: 2, 3: 1}, nil)
+
+		req, err := http.NewRequest("POST", server.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "application/json")
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		var actual *models.Games
+		err = json.NewDecoder(resp.Body).Decode(&actual)
+		require.NoError(t, err)
+		require.Equal(t, models.NewGames(map[int]int{1: 2, 3: 1}), actual)
+
+		_, gameId := app.RestartArgsForCall(app.RestartCallCount() - 1)
+		require.Equal(t, 0, gameId)
+	})
+
+	t.Run("game", func(t *testing.T) {
+		app.RestartReturns(map[int]int{3: 1}, nil)
+
+		form := url.Values{}
+		form.Add("game", "3")
+		resp, err := server.Client().PostForm(server.URL, form)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		_, gameId := app.RestartArgsForCall(app.RestartCallCount() - 1)
+		require.Equal(t, 3, gameId)
+	})
+
+	t.Run("invalid game", func(t *testing.T) {
+		resp, err := server.Client().Post(server.URL+"?game=abc", "", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("restarting", func(t *testing.T) {
+		app.RestartReturns(nil, core.ErrRestarting)
+
+		resp, err := server.Client().Post(server.URL, "", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		app.RestartReturns(nil, core.ErrDraining)
+
+		resp, err := server.Client().Post(server.URL, "", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	})
+}
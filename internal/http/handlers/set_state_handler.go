@@ -18,8 +18,12 @@ import (
 
 //counterfeiter:generate . AppSetState
 type AppSetState interface {
-	SetState(ctx context.Context, state map[int]int) (map[int]int, error)
-	SetOne(ctx context.Context, gameId, botsNumber int) (map[int]int, error)
+	SetGames(ctx context.Context, state map[int]int,
+		settings map[int]*models.GameSettings) (map[int]int, error)
+	SetOne(ctx context.Context, gameId, botsNumber int,
+		settings *models.GameSettings) (map[int]int, error)
+	GetSettings(ctx context.Context) map[int]*models.GameSettings
+	GetSeeds(ctx context.Context) map[int][]int64
 }
 
 type SetStateHandler struct {
@@ -91,7 +95,9 @@ func (h *SetStateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data := models.NewGames(state)
+	settings := h.app.GetSettings(ctx)
+	seeds := h.app.GetSeeds(ctx)
+	data := models.NewGames(state).WithSettings(settings).WithSeeds(seeds)
 
 	respond(w, r, http.StatusCreated, data)
 }
@@ -101,10 +107,22 @@ func appSetStateErrStatus(err error) int {
 		return http.StatusBadRequest
 	}
 
+	if errors.Is(err, core.ErrUnknownProfile) {
+		return http.StatusBadRequest
+	}
+
+	if errors.Is(err, core.ErrUnknownDiscoverer) {
+		return http.StatusBadRequest
+	}
+
 	if errors.Is(err, context.DeadlineExceeded) {
 		return http.StatusServiceUnavailable
 	}
 
+	if errors.Is(err, core.ErrDraining) {
+		return http.StatusServiceUnavailable
+	}
+
 	return http.StatusInternalServerError
 }
 
@@ -132,7 +150,16 @@ func (h *SetStateHandler) handleFormUrlencoded(
 		return nil, http.StatusBadRequest, errors.Wrap(err, "parse bots number fail")
 	}
 
-	state, err := h.app.SetOne(ctx, gameId, bots)
+	// The settings of the game are replaced only if any is given.
+	var settings *models.GameSettings
+	if r.PostForm.Has("profiles") || r.PostForm.Has("discoverer") {
+		settings = &models.GameSettings{
+			Profiles:   r.PostForm["profiles"],
+			Discoverer: r.PostForm.Get("discoverer"),
+		}
+	}
+
+	state, err := h.app.SetOne(ctx, gameId, bots, settings)
 	if err != nil {
 		return nil, appSetStateErrStatus(err), errors.Wrap(err, "set one fail")
 	}
@@ -157,7 +184,8 @@ func (h *SetStateHandler) handleJson(
 		return nil, http.StatusBadRequest, errors.Wrap(err, "decode json fail")
 	}
 
-	state, err := h.app.SetState(ctx, games.ToMapState())
+	state, err := h.app.SetGames(ctx, games.ToMapState(),
+		games.ToMapSettings())
 	if err != nil {
 		return nil, appSetStateErrStatus(err), errors.Wrap(err, "set state fail")
 	}
@@ -182,7 +210,8 @@ func (h *SetStateHandler) handleYaml(
 		return nil, http.StatusBadRequest, errors.Wrap(err, "decode yaml fail")
 	}
 
-	state, err := h.app.SetState(ctx, games.ToMapState())
+	state, err := h.app.SetGames(ctx, games.ToMapState(),
+		games.ToMapSettings())
 	if err != nil {
 		return nil, appSetStateErrStatus(err), errors.Wrap(err, "set state fail")
 	}
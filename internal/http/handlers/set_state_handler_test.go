@@ -14,6 +14,7 @@ import (
 	"github.com/ivan1993spb/snake-bot/internal/core"
 	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
 	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
 )
 
 func Test_SetStateHandler_XWWWFormURLEncoded(t *testing.T) {
@@ -46,11 +47,13 @@ func Test_SetStateHandler_XWWWFormURLEncoded(t *testing.T) {
 
 	require.Equal(t, expectBody, buffer.String())
 	require.Equal(t, 1, app.SetOneCallCount())
+	_, _, _, settings := app.SetOneArgsForCall(0)
+	require.Nil(t, settings)
 }
 
 func Test_SetStateHandler_Json(t *testing.T) {
 	app := &handlersfakes.FakeAppSetState{}
-	app.SetStateReturns(map[int]int{
+	app.SetGamesReturns(map[int]int{
 		1: 1,
 		2: 21,
 	}, nil)
@@ -77,12 +80,12 @@ func Test_SetStateHandler_Json(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t, expectBody, buffer.String())
-	require.Equal(t, 1, app.SetStateCallCount())
+	require.Equal(t, 1, app.SetGamesCallCount())
 }
 
 func Test_SetStateHandler_Yaml(t *testing.T) {
 	app := &handlersfakes.FakeAppSetState{}
-	app.SetStateReturns(map[int]int{
+	app.SetGamesReturns(map[int]int{
 		1:  51,
 		2:  2,
 		15: 25,
@@ -111,7 +114,7 @@ func Test_SetStateHandler_Yaml(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t, expectBody, buffer.String())
-	require.Equal(t, 1, app.SetStateCallCount())
+	require.Equal(t, 1, app.SetGamesCallCount())
 }
 
 func Test_SetStateHandler_MediaDeadbeef(t *testing.T) {
@@ -130,7 +133,7 @@ func Test_SetStateHandler_MediaDeadbeef(t *testing.T) {
 
 func Test_SetStateHandler_MediaYaml_AcceptJson(t *testing.T) {
 	app := &handlersfakes.FakeAppSetState{}
-	app.SetStateReturns(map[int]int{
+	app.SetGamesReturns(map[int]int{
 		16: 1,
 		2:  21,
 		31: 8,
@@ -162,12 +165,12 @@ func Test_SetStateHandler_MediaYaml_AcceptJson(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t, expectBody, buffer.String())
-	require.Equal(t, 1, app.SetStateCallCount())
+	require.Equal(t, 1, app.SetGamesCallCount())
 }
 
 func Test_SetStateHandler_AppError(t *testing.T) {
 	app := &handlersfakes.FakeAppSetState{}
-	app.SetStateReturns(nil, errors.New("app error"))
+	app.SetGamesReturns(nil, errors.New("app error"))
 
 	server := httptest.NewServer(handlers.NewSetStateHandler(app))
 	defer server.Close()
@@ -181,7 +184,7 @@ func Test_SetStateHandler_AppError(t *testing.T) {
 	defer resp.Body.Close()
 
 	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-	require.Equal(t, 1, app.SetStateCallCount())
+	require.Equal(t, 1, app.SetGamesCallCount())
 }
 
 func Test_SetStateHandler_TooManyBots(t *testing.T) {
@@ -225,3 +228,140 @@ func Test_SetStateHandler_ServiceUnavailable(t *testing.T) {
 	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
 	require.Equal(t, 1, app.SetOneCallCount())
 }
+
+func Test_SetStateHandler_Draining(t *testing.T) {
+	app := &handlersfakes.FakeAppSetState{}
+	app.SetOneReturns(nil, core.ErrDraining)
+
+	server := httptest.NewServer(handlers.NewSetStateHandler(app))
+	defer server.Close()
+
+	form := url.Values{}
+	form.Add("game", "1")
+	form.Add("bots", "1")
+
+	resp, err := server.Client().PostForm(server.URL, form)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func Test_SetStateHandler_Json_Profiles(t *testing.T) {
+	app := &handlersfakes.FakeAppSetState{}
+	app.SetGamesReturns(map[int]int{
+		1: 3,
+		2: 1,
+	}, nil)
+	app.GetSettingsReturns(map[int]*models.GameSettings{
+		1: {
+			Profiles:   []string{"greedy", "hunter"},
+			Discoverer: "mcts",
+		},
+	})
+
+	expectBody := `{"games":[{"game":1,"bots":3,"profiles":["greedy","hunter"],"discoverer":"mcts"},{"game":2,"bots":1}]}` + "\n"
+
+	server := httptest.NewServer(handlers.NewSetStateHandler(app))
+	defer server.Close()
+
+	data := []byte(`{"games":[{"game":1,"bots":3,"profiles":["greedy","hunter"],"discoverer":"mcts"},{"game":2,"bots":1}]}`)
+	buffer := bytes.NewBuffer(data)
+	resp, err := server.Client().Post(server.URL, "application/json", buffer)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	defer resp.Body.Close()
+
+	require.Equal(t, 201, resp.StatusCode)
+
+	buffer = bytes.NewBuffer(nil)
+	_, err = buffer.ReadFrom(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, expectBody, buffer.String())
+
+	require.Equal(t, 1, app.SetGamesCallCount())
+	_, state, settings := app.SetGamesArgsForCall(0)
+	require.Equal(t, map[int]int{
+		1: 3,
+		2: 1,
+	}, state)
+	require.Equal(t, map[int]*models.GameSettings{
+		1: {
+			Profiles:   []string{"greedy", "hunter"},
+			Discoverer: "mcts",
+		},
+		2: {},
+	}, settings)
+}
+
+func Test_SetStateHandler_XWWWFormURLEncoded_Profiles(t *testing.T) {
+	app := &handlersfakes.FakeAppSetState{}
+	app.SetOneReturns(map[int]int{
+		1: 2,
+	}, nil)
+
+	server := httptest.NewServer(handlers.NewSetStateHandler(app))
+	defer server.Close()
+
+	form := url.Values{}
+	form.Add("game", "1")
+	form.Add("bots", "2")
+	form.Add("profiles", "coward")
+	form.Add("profiles", "hunter")
+	form.Add("discoverer", "lookahead")
+
+	resp, err := server.Client().PostForm(server.URL, form)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	defer resp.Body.Close()
+
+	require.Equal(t, 201, resp.StatusCode)
+
+	require.Equal(t, 1, app.SetOneCallCount())
+	_, gameId, bots, settings := app.SetOneArgsForCall(0)
+	require.Equal(t, 1, gameId)
+	require.Equal(t, 2, bots)
+	require.Equal(t, &models.GameSettings{
+		Profiles:   []string{"coward", "hunter"},
+		Discoverer: "lookahead",
+	}, settings)
+}
+
+func Test_SetStateHandler_UnknownProfile(t *testing.T) {
+	app := &handlersfakes.FakeAppSetState{}
+	app.SetGamesReturns(nil, core.ErrUnknownProfile)
+
+	server := httptest.NewServer(handlers.NewSetStateHandler(app))
+	defer server.Close()
+
+	data := []byte("games:\n  - game: 1\n    bots: 1\n    profiles: [wizard]")
+	buffer := bytes.NewBuffer(data)
+	resp, err := server.Client().Post(server.URL, "text/yaml", buffer)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Equal(t, 1, app.SetGamesCallCount())
+}
+
+func Test_SetStateHandler_UnknownDiscoverer(t *testing.T) {
+	app := &handlersfakes.FakeAppSetState{}
+	app.SetOneReturns(nil, core.ErrUnknownDiscoverer)
+
+	server := httptest.NewServer(handlers.NewSetStateHandler(app))
+	defer server.Close()
+
+	form := url.Values{}
+	form.Add("game", "1")
+	form.Add("bots", "2")
+	form.Add("discoverer", "compass")
+
+	resp, err := server.Client().PostForm(server.URL, form)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
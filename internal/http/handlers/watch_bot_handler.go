@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+//counterfeiter:generate . AppWatchBot
+type AppWatchBot interface {
+	WatchBot(ctx context.Context, gameId, botId int) (
+		<-chan *models.BotView, error)
+}
+
+type WatchBotHandler struct {
+	app      AppWatchBot
+	upgrader websocket.Upgrader
+}
+
+// NewWatchBotHandler streams what a bot sees and decides on every tick
+// over a websocket. Unless forbidCORS is set, connections are accepted
+// from any origin, the same way the rest of the API is served.
+func NewWatchBotHandler(app AppWatchBot, forbidCORS bool) http.Handler {
+	h := &WatchBotHandler{
+		app: app,
+	}
+	if !forbidCORS {
+		h.upgrader.CheckOrigin = func(*http.Request) bool {
+			return true
+		}
+	}
+	return h
+}
+
+// URLParamBot is the name of the URL parameter holding a bot id.
+const URLParamBot = "bot"
+
+func (h *WatchBotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	ctx = utils.WithModule(ctx, "watch_bot_handler")
+	log := utils.GetLogger(ctx)
+
+	log.Info("watch bot handler started")
+	defer log.Info("watch bot handler stopped")
+
+	gameId, err := strconv.Atoi(chi.URLParam(r, URLParamGame))
+	if err != nil || gameId <= 0 {
+		log.WithError(err).Error("parse game id fail")
+		respondError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	botId, err := strconv.Atoi(chi.URLParam(r, URLParamBot))
+	if err != nil || botId <= 0 {
+		log.WithError(err).Error("parse bot id fail")
+		respondError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	views, err := h.app.WatchBot(ctx, gameId, botId)
+	if err != nil {
+		log.WithError(err).Error("watch bot fail")
+		if errors.Is(err, core.ErrBotNotFound) {
+			respondError(w, r, http.StatusNotFound)
+			return
+		}
+		respondError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.WithError(err).Error("upgrade fail")
+		return
+	}
+	defer conn.Close()
+
+	// The client is not expected to send anything. The messages are
+	// read to notice when the client goes away.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case view, ok := <-views:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(view); err != nil {
+				log.WithError(err).Error("write view fail")
+				return
+			}
+		}
+	}
+}
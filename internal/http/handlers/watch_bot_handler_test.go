@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/core"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers"
+	"github.com/ivan1993spb/snake-bot/internal/http/handlers/handlersfakes"
+	"github.com/ivan1993spb/snake-bot/internal/models"
+)
+
+func Test_WatchBotHandler(t *testing.T) {
+	views := make(chan *models.BotView, 1)
+	watched := make(chan context.Context, 1)
+	app := &handlersfakes.FakeAppWatchBot{}
+	app.WatchBotStub = func(ctx context.Context, gameId, botId int) (
+		<-chan *models.BotView, error) {
+		if botId != 2 {
+			return nil, core.ErrBotNotFound
+		}
+		watched <- ctx
+		return views, nil
+	}
+
+	r := chi.NewRouter()
+	r.Method("GET", "/{"+handlers.URLParamGame+"}/{"+
+		handlers.URLParamBot+"}/view", handlers.NewWatchBotHandler(app, false))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	t.Run("view", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+"/3/2/view", nil)
+		require.NoError(t, err)
+
+		view := &models.BotView{
+			Snake: 7,
+			Head:  models.ViewDot{4, 5},
+			Sight: models.ViewSight{
+				TopLeft: models.ViewDot{0, 1},
+				Width:   9,
+				Height:  9,
+			},
+			Scores: []models.ScoredDot{
+				{Dot: models.ViewDot{5, 5}, Score: 10},
+			},
+			Path:      []models.ViewDot{{5, 5}},
+			Direction: "east",
+		}
+		views <- view
+
+		var actual *models.BotView
+		require.NoError(t, conn.ReadJSON(&actual))
+		require.Equal(t, view, actual)
+
+		_, gameId, botId := app.WatchBotArgsForCall(0)
+		require.Equal(t, 3, gameId)
+		require.Equal(t, 2, botId)
+
+		// Closing the connection stops the watching.
+		ctx := <-watched
+		require.NoError(t, conn.Close())
+		<-ctx.Done()
+	})
+
+	t.Run("cross origin", func(t *testing.T) {
+		header := http.Header{"Origin": {"http://example.com"}}
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+"/3/2/view",
+			header)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	})
+
+	t.Run("bot not found", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "/3/4/view")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("invalid bot", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "/3/abc/view")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func Test_WatchBotHandler_ForbidCORS(t *testing.T) {
+	app := &handlersfakes.FakeAppWatchBot{}
+	app.WatchBotReturns(make(chan *models.BotView), nil)
+
+	r := chi.NewRouter()
+	r.Method("GET", "/{"+handlers.URLParamGame+"}/{"+
+		handlers.URLParamBot+"}/view", handlers.NewWatchBotHandler(app, true))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	header := http.Header{"Origin": {"http://example.com"}}
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL+"/3/2/view", header)
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL+"/3/2/view", nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
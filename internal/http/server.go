@@ -20,7 +20,16 @@ import (
 
 type Core interface {
 	handlers.AppGetState
+	handlers.AppGetBots
 	handlers.AppSetState
+	handlers.AppGetGames
+	handlers.AppGetRemovedGames
+	handlers.AppFill
+	handlers.AppEvents
+	handlers.AppWatchBot
+	handlers.AppRenderBot
+	handlers.AppDrain
+	handlers.AppRestart
 }
 
 type Secure interface {
@@ -83,6 +92,43 @@ func (s *Server) initRoutes() http.Handler {
 			middleware.Throttle(requestPostBotsThrottleLimit),
 		).Method("POST", "/", handlers.NewSetStateHandler(s.params.Core))
 		r.Method("GET", "/", handlers.NewGetStateHandler(s.params.Core))
+		r.Method("POST", "/restart", handlers.NewRestartHandler(s.params.Core))
+		r.Method("GET", "/{"+handlers.URLParamGame+"}",
+			handlers.NewGetBotsHandler(s.params.Core))
+		r.Method("GET", "/{"+handlers.URLParamGame+"}/{"+
+			handlers.URLParamBot+"}/view",
+			handlers.NewWatchBotHandler(s.params.Core,
+				s.params.Config.ForbidCORS))
+		r.With(middleware.NoCache).Method("GET", "/{"+handlers.URLParamGame+
+			"}/{"+handlers.URLParamBot+"}/view.png",
+			handlers.NewRenderBotHandler(s.params.Core))
+	})
+
+	r.Route("/api/games", func(r chi.Router) {
+		r.Use(middlewares.JwtTokenAuth(s.params.Secure))
+		r.Method("GET", "/", handlers.NewGetGamesHandler(s.params.Core))
+		r.Method("GET", "/removed", handlers.NewGetRemovedGamesHandler(s.params.Core))
+	})
+
+	r.Route("/api/fill", func(r chi.Router) {
+		r.Use(middlewares.JwtTokenAuth(s.params.Secure))
+		r.With(
+			middleware.AllowContentType(
+				"application/json",
+				"text/yaml",
+			),
+		).Method("POST", "/", handlers.NewSetFillHandler(s.params.Core))
+		r.Method("GET", "/", handlers.NewGetFillHandler(s.params.Core))
+	})
+
+	r.Route("/api/events", func(r chi.Router) {
+		r.Use(middlewares.JwtTokenAuth(s.params.Secure))
+		r.Method("GET", "/", handlers.NewEventsHandler(s.params.Core))
+	})
+
+	r.Route("/api/drain", func(r chi.Router) {
+		r.Use(middlewares.JwtTokenAuth(s.params.Secure))
+		r.Method("POST", "/", handlers.NewDrainHandler(s.params.Core))
 	})
 
 	if s.params.Config.Debug {
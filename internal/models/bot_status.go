@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Statuses of a bot.
+const (
+	// BotStatusWaiting means the bot waits to connect.
+	BotStatusWaiting = "waiting"
+	// BotStatusConnecting means the bot dials the server.
+	BotStatusConnecting = "connecting"
+	// BotStatusBackingOff means the bot waits to retry connecting.
+	BotStatusBackingOff = "backing_off"
+	// BotStatusConnected means the bot waits for its snake.
+	BotStatusConnected = "connected"
+	// BotStatusPlaying means the snake of the bot is alive.
+	BotStatusPlaying = "playing"
+	// BotStatusDead means the snake of the bot waits to respawn.
+	BotStatusDead = "dead"
+	// BotStatusStopped means the bot does not play anymore.
+	BotStatusStopped = "stopped"
+)
+
+// BotStatus describes a running bot.
+type BotStatus struct {
+	Id     int    `json:"id" yaml:"id"`
+	Status string `json:"status" yaml:"status"`
+	Seed   int64  `json:"seed" yaml:"seed"`
+	// Snake is the id of the bot's snake in the game.
+	Snake  uint32 `json:"snake,omitempty" yaml:"snake,omitempty"`
+	Length int    `json:"length" yaml:"length"`
+	// Started is the start of the current session.
+	Started    time.Time `json:"started,omitempty" yaml:"started,omitempty"`
+	Reconnects int       `json:"reconnects" yaml:"reconnects"`
+	LastError  string    `json:"last_error,omitempty" yaml:"last_error,omitempty"`
+	// Sessions are the recent finished sessions, the latest last.
+	Sessions []*BotSession `json:"sessions,omitempty" yaml:"sessions,omitempty"`
+}
+
+// BotSession is a finished session of a bot.
+type BotSession struct {
+	Started time.Time `json:"started" yaml:"started"`
+	Ended   time.Time `json:"ended" yaml:"ended"`
+	Error   string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// GameBots lists the bots of a game.
+type GameBots struct {
+	Game int          `json:"game" yaml:"game"`
+	Bots []*BotStatus `json:"bots" yaml:"bots"`
+}
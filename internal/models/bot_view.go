@@ -0,0 +1,30 @@
+package models
+
+// ViewDot is a dot encoded as [x,y] like the dots sent by Snake-Server.
+type ViewDot [2]uint8
+
+// ViewSight is the window of the map a bot sees.
+type ViewSight struct {
+	// TopLeft is the top left dot of the window. The window wraps
+	// around the edges of the map.
+	TopLeft ViewDot `json:"top_left" yaml:"top_left"`
+	Width   int     `json:"width" yaml:"width"`
+	Height  int     `json:"height" yaml:"height"`
+}
+
+// ScoredDot is a dot a bot has scored.
+type ScoredDot struct {
+	Dot   ViewDot `json:"dot" yaml:"dot"`
+	Score int     `json:"score" yaml:"score"`
+}
+
+// BotView is what a bot sees and decides on a tick.
+type BotView struct {
+	Snake  uint32      `json:"snake" yaml:"snake"`
+	Head   ViewDot     `json:"head" yaml:"head"`
+	Sight  ViewSight   `json:"sight" yaml:"sight"`
+	Scores []ScoredDot `json:"scores" yaml:"scores"`
+	// Path is the path the bot has chosen, the next dot first.
+	Path      []ViewDot `json:"path" yaml:"path"`
+	Direction string    `json:"direction,omitempty" yaml:"direction,omitempty"`
+}
@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// Drain describes the draining of the service.
+type Drain struct {
+	// Deadline is the time the remaining bots are stopped at.
+	Deadline time.Time `json:"deadline" yaml:"deadline"`
+}
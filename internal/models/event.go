@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Types of the events of bots.
+const (
+	// EventSpawned is published when a bot starts.
+	EventSpawned = "spawned"
+	// EventConnecting is published on every connection attempt.
+	EventConnecting = "connecting"
+	// EventConnected is published when a bot joins the game.
+	EventConnected = "connected"
+	// EventSnake is published when a bot gets a snake.
+	EventSnake = "snake"
+	// EventCountdown is published when the server counts down to
+	// the respawn of a snake.
+	EventCountdown = "countdown"
+	// EventDied is published when the snake of a bot dies.
+	EventDied = "died"
+	// EventReconnecting is published when a bot is going to connect
+	// again after a failure.
+	EventReconnecting = "reconnecting"
+	// EventStopped is published when a bot stops.
+	EventStopped = "stopped"
+)
+
+// Event is an event in the life of a bot.
+type Event struct {
+	Type string    `json:"type" yaml:"type"`
+	Time time.Time `json:"time" yaml:"time"`
+	Game int       `json:"game" yaml:"game"`
+	// Bot is the id of the bot.
+	Bot       int    `json:"bot" yaml:"bot"`
+	Snake     uint32 `json:"snake,omitempty" yaml:"snake,omitempty"`
+	Countdown int    `json:"countdown,omitempty" yaml:"countdown,omitempty"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
@@ -0,0 +1,43 @@
+package models
+
+// FillPolicy defines how many bots to keep in the games of the target
+// server. A game gets the greater of the numbers of bots required by
+// Occupancy and by Players within MinBots and MaxBots, but bots never
+// take the seats of humans.
+type FillPolicy struct {
+	// Occupancy is the share of the players limit of a game to be
+	// taken by bots: from 0 to 1.
+	Occupancy float64 `json:"occupancy" yaml:"occupancy"`
+	// Players is the number of players bots keep in a game together
+	// with humans.
+	Players int `json:"players" yaml:"players"`
+	// Yield makes every human in a game replace a bot required by
+	// Occupancy, so bots leave as humans join and come back as they
+	// leave.
+	Yield bool `json:"yield,omitempty" yaml:"yield,omitempty"`
+	// MinBots and MaxBots bound the number of bots per game. Zero
+	// MaxBots means no upper bound.
+	MinBots int `json:"min_bots,omitempty" yaml:"min_bots,omitempty"`
+	MaxBots int `json:"max_bots,omitempty" yaml:"max_bots,omitempty"`
+	// Games limits the policy to the listed games. If the list is
+	// empty, the policy covers all the games of the target server.
+	Games []int `json:"games,omitempty" yaml:"games,omitempty"`
+}
+
+// Enabled reports whether the policy requires any bots.
+func (p *FillPolicy) Enabled() bool {
+	return p != nil && (p.Occupancy > 0 || p.Players > 0 || p.MinBots > 0)
+}
+
+// Covers reports whether the policy applies to the game.
+func (p *FillPolicy) Covers(gameId int) bool {
+	if len(p.Games) == 0 {
+		return true
+	}
+	for _, id := range p.Games {
+		if id == gameId {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,37 @@
 package models
 
+import "slices"
+
 type Game struct {
 	Game int `json:"game" yaml:"game"`
 	Bots int `json:"bots" yaml:"bots"`
+
+	Profiles   []string `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+	Discoverer string   `json:"discoverer,omitempty" yaml:"discoverer,omitempty"`
+	Seeds      []int64  `json:"seeds,omitempty" yaml:"seeds,omitempty"`
+}
+
+// GameSettings are the settings of the bots of a game. The bots take
+// the profiles in turn. Discoverer overrides the path discovery
+// algorithm of the bots if it is set.
+type GameSettings struct {
+	Profiles   []string
+	Discoverer string
+}
+
+// IsZero reports whether the settings are the default ones.
+func (s *GameSettings) IsZero() bool {
+	return len(s.Profiles) == 0 && s.Discoverer == ""
+}
+
+func (s *GameSettings) Copy() *GameSettings {
+	return &GameSettings{
+		Profiles:   append([]string(nil), s.Profiles...),
+		Discoverer: s.Discoverer,
+	}
+}
+
+func (s *GameSettings) Equal(other *GameSettings) bool {
+	return slices.Equal(s.Profiles, other.Profiles) &&
+		s.Discoverer == other.Discoverer
 }
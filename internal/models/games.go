@@ -32,3 +32,35 @@ func (g *Games) ToMapState() map[int]int {
 	}
 	return state
 }
+
+// WithSettings sets the profiles and the discoverers of the games.
+func (g *Games) WithSettings(settings map[int]*GameSettings) *Games {
+	for _, game := range g.Games {
+		if s, ok := settings[game.Game]; ok {
+			game.Profiles = s.Profiles
+			game.Discoverer = s.Discoverer
+		}
+	}
+	return g
+}
+
+// WithSeeds sets the seeds of the games' bots.
+func (g *Games) WithSeeds(seeds map[int][]int64) *Games {
+	for _, game := range g.Games {
+		game.Seeds = seeds[game.Game]
+	}
+	return g
+}
+
+// ToMapSettings returns the settings of every listed game. Games with
+// no profiles and no discoverer get the default settings.
+func (g *Games) ToMapSettings() map[int]*GameSettings {
+	settings := make(map[int]*GameSettings, len(g.Games))
+	for _, game := range g.Games {
+		settings[game.Game] = &GameSettings{
+			Profiles:   game.Profiles,
+			Discoverer: game.Discoverer,
+		}
+	}
+	return settings
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// RemovedGame is a game removed from the state because the target server
+// does not have it.
+type RemovedGame struct {
+	Game int `json:"game" yaml:"game"`
+	// Bots is the number of the bots the game had.
+	Bots    int       `json:"bots" yaml:"bots"`
+	Removed time.Time `json:"removed" yaml:"removed"`
+}
+
+type RemovedGames struct {
+	Games []*RemovedGame `json:"games" yaml:"games"`
+}
+
+func NewRemovedGames(games map[int]*RemovedGame) *RemovedGames {
+	g := &RemovedGames{}
+	g.Games = make([]*RemovedGame, 0, len(games))
+	for _, game := range games {
+		copied := *game
+		g.Games = append(g.Games, &copied)
+	}
+
+	sort.Slice(g.Games, func(i, j int) bool {
+		return g.Games[i].Game < g.Games[j].Game
+	})
+
+	return g
+}
@@ -0,0 +1,18 @@
+package models
+
+// ServerGame is a game on the target server.
+type ServerGame struct {
+	Id     int   `json:"id" yaml:"id"`
+	Width  uint8 `json:"width" yaml:"width"`
+	Height uint8 `json:"height" yaml:"height"`
+	// Limit is the players limit of the game.
+	Limit int `json:"limit" yaml:"limit"`
+	// Count is the number of the players in the game including bots.
+	Count int `json:"count" yaml:"count"`
+	// Bots is the number of the bots of the service in the game.
+	Bots int `json:"bots" yaml:"bots"`
+}
+
+type ServerGames struct {
+	Games []*ServerGame `json:"games" yaml:"games"`
+}
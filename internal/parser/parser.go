@@ -30,12 +30,20 @@ type Printer interface {
 	Print(level, what, message string)
 }
 
+// Observer is notified of every parsed message. The message type is empty
+// if the message is not valid JSON.
+type Observer interface {
+	Observe(messageType types.MessageType, err error)
+}
+
 type Parser struct {
 	Countdown
 	Me
 	Size
 	Game
 	Printer
+	// Observer is optional.
+	Observer
 }
 
 const errParseAnnotation = "parsing error"
@@ -44,28 +52,36 @@ var ErrParseUnknownMessage = errors.Errorf("%s: %s", errParseAnnotation,
 	"unknown message")
 
 func (p *Parser) Parse(data []byte) error {
+	messageType, err := p.parse(data)
+	if p.Observer != nil {
+		p.Observer.Observe(messageType, err)
+	}
+	return err
+}
+
+func (p *Parser) parse(data []byte) (types.MessageType, error) {
 	var message *types.Message
 	if err := json.Unmarshal(data, &message); err != nil {
-		return errors.Wrap(err, errParseAnnotation)
+		return "", errors.Wrap(err, errParseAnnotation)
 	}
 	switch message.Type {
 	case types.MessageTypeGameEvent:
 		if err := p.ParseGameEvent(message.Payload); err != nil {
-			return errors.Wrap(err, errParseAnnotation)
+			return message.Type, errors.Wrap(err, errParseAnnotation)
 		}
 	case types.MessageTypePlayer:
 		if err := p.ParsePlayerEvent(message.Payload); err != nil {
-			return errors.Wrap(err, errParseAnnotation)
+			return message.Type, errors.Wrap(err, errParseAnnotation)
 		}
 	case types.MessageTypeBroadcast:
 		if err := p.ParseBroadcast(message.Payload); err != nil {
-			return errors.Wrap(err, errParseAnnotation)
+			return message.Type, errors.Wrap(err, errParseAnnotation)
 		}
 	default:
-		return errors.WithMessagef(ErrParseUnknownMessage, "unkown type %q",
-			message.Type)
+		return message.Type, errors.WithMessagef(ErrParseUnknownMessage,
+			"unkown type %q", message.Type)
 	}
-	return nil
+	return message.Type, nil
 }
 
 const errParseGameEventAnnotation = "cannot parse game event"
@@ -53,3 +53,11 @@ type MockPrinter struct {
 func (m *MockPrinter) Print(level, what, message string) {
 	m.Called(level, what, message)
 }
+
+type MockObserver struct {
+	mock.Mock
+}
+
+func (m *MockObserver) Observe(messageType types.MessageType, err error) {
+	m.Called(messageType, err)
+}
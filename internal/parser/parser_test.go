@@ -4,7 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
 )
 
 type ParserTestSuite struct {
@@ -34,6 +37,27 @@ func (suite *ParserTestSuite) TestParseBroadcastCorruptedPayload() {
 	assert.NotNil(suite.T(), err)
 }
 
+func (suite *ParserTestSuite) TestParseObserved() {
+	printer := new(MockPrinter)
+	suite.Parser.Printer = printer
+	printer.On("Print", "broadcast", "message", "user joined your game group").Return()
+	observer := new(MockObserver)
+	suite.Parser.Observer = observer
+	observer.On("Observe", types.MessageTypeBroadcast, nil).Return()
+	observer.On("Observe", types.MessageType("unknown"), mock.Anything).Return()
+	observer.On("Observe", types.MessageType(""), mock.Anything).Return()
+
+	err := suite.Parser.Parse([]byte(`{"type":"broadcast","payload":"user joined your game group"}`))
+	assert.Nil(suite.T(), err)
+	err = suite.Parser.Parse([]byte(`{"type":"unknown","payload":""}`))
+	assert.NotNil(suite.T(), err)
+	err = suite.Parser.Parse([]byte(`{"type":`))
+	assert.NotNil(suite.T(), err)
+
+	observer.AssertNumberOfCalls(suite.T(), "Observe", 3)
+	observer.AssertExpectations(suite.T())
+}
+
 func TestParserTestSuite(t *testing.T) {
 	suite.Run(t, new(ParserTestSuite))
 }
@@ -0,0 +1,88 @@
+package replay
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/parser"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+// Config describes the bot a recorded session is replayed into.
+type Config struct {
+	Discoverer string
+	Profile    *bot.Profile
+	Seed       int64
+	// Clock paces the recorded messages. utils.RealClock keeps the
+	// recorded pauses, utils.ImmediatelyClock feeds the messages at once.
+	Clock utils.Clock
+}
+
+// Result compares the commands of the replayed bot with the recorded
+// ones.
+type Result struct {
+	// Received is the number of the messages fed to the bot.
+	Received int
+	// Recorded are the commands sent in the recorded session.
+	Recorded []connect.Record
+	// Sent are the commands sent by the replayed bot.
+	Sent []connect.Record
+}
+
+// Run feeds the received messages of the recording to a new bot. The bot
+// looks around after every message, so with a deterministic profile
+// a replay of a recording always sends the same commands.
+func Run(ctx context.Context, r io.Reader, config Config) (*Result, error) {
+	log := utils.GetLogger(ctx)
+
+	world := bot.NewGame()
+	b, err := bot.NewBotWithDiscoverer(world, config.Discoverer,
+		config.Profile, config.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser.Parser{
+		Countdown: b,
+		Me:        b,
+		Size:      world,
+		Game:      world,
+		Printer:   utils.PrinterDevNull{},
+	}
+
+	conn := connect.NewReplayConnection(r, config.Clock)
+	result := &Result{}
+
+	for {
+		message, err := conn.Receive(ctx)
+		if err == connect.ErrConnectionClosed {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "replay")
+		}
+		result.Received++
+
+		if err := p.Parse(message); err != nil {
+			// The bot skips the messages it cannot parse.
+			log.WithError(err).Warn("parse fail")
+			continue
+		}
+
+		if direction, ok := b.Tick(ctx); ok {
+			err := conn.Send(ctx, direction.ToMessageSnakeCommand())
+			if err != nil {
+				return nil, errors.Wrap(err, "replay")
+			}
+		}
+	}
+
+	result.Recorded = conn.Recorded()
+	result.Sent = conn.Sent()
+
+	return result, nil
+}
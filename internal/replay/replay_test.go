@@ -0,0 +1,116 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/connect"
+	"github.com/ivan1993spb/snake-bot/internal/parser"
+	"github.com/ivan1993spb/snake-bot/internal/simulator"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+// record plays a simulated game with a bot which looks around after
+// every message and records the session.
+func record(t *testing.T, profile *bot.Profile, seed int64) []byte {
+	ctx := context.Background()
+
+	sim := simulator.New(simulator.Config{
+		Width:  20,
+		Height: 20,
+		Walls:  3,
+		Apples: 10,
+		Mice:   2,
+		Seed:   seed,
+	})
+	player := sim.Join()
+
+	world := bot.NewGame()
+	b, err := bot.NewBotWithDiscoverer(world, bot.DiscovererDijkstras,
+		profile, seed)
+	require.Nil(t, err)
+	p := &parser.Parser{
+		Countdown: b,
+		Me:        b,
+		Size:      world,
+		Game:      world,
+		Printer:   utils.PrinterDevNull{},
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for tick := 0; tick < 50; tick++ {
+		for _, message := range player.Receive() {
+			require.Nil(t, encoder.Encode(&connect.Record{
+				Time:    now,
+				Kind:    connect.RecordKindReceive,
+				Message: message,
+			}))
+			require.Nil(t, p.Parse(message))
+
+			if direction, ok := b.Tick(ctx); ok {
+				player.Send(direction)
+				command, err := json.Marshal(
+					direction.ToMessageSnakeCommand())
+				require.Nil(t, err)
+				require.Nil(t, encoder.Encode(&connect.Record{
+					Time:    now,
+					Kind:    connect.RecordKindSend,
+					Message: command,
+				}))
+			}
+		}
+		sim.Tick()
+		now = now.Add(time.Millisecond * 200)
+	}
+
+	return buf.Bytes()
+}
+
+func Test_Run(t *testing.T) {
+	ctx := utils.WithLogger(context.Background(), utils.DiscardEntry)
+
+	profile, ok := bot.NewProfiles().Get("")
+	require.True(t, ok)
+	deterministic := *profile
+	deterministic.Deterministic = true
+
+	recording := record(t, &deterministic, 5)
+
+	result, err := Run(ctx, bytes.NewReader(recording), Config{
+		Discoverer: bot.DiscovererDijkstras,
+		Profile:    &deterministic,
+		Seed:       5,
+		Clock:      utils.ImmediatelyClock,
+	})
+	require.Nil(t, err)
+	require.Positive(t, result.Received)
+	require.NotEmpty(t, result.Recorded)
+
+	// The replayed bot makes the same decisions.
+	require.Len(t, result.Sent, len(result.Recorded))
+	for i := range result.Sent {
+		require.JSONEq(t, string(result.Recorded[i].Message),
+			string(result.Sent[i].Message))
+	}
+}
+
+func Test_Run_UnknownDiscoverer(t *testing.T) {
+	profile, ok := bot.NewProfiles().Get("")
+	require.True(t, ok)
+
+	_, err := Run(context.Background(), bytes.NewReader(nil), Config{
+		Discoverer: "compass",
+		Profile:    profile,
+		Clock:      utils.ImmediatelyClock,
+	})
+	require.ErrorIs(t, err, bot.ErrUnknownDiscoverer)
+}
@@ -0,0 +1,104 @@
+package simulator
+
+import (
+	"encoding/json"
+
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+// object is an object of the simulated game. The first dot of a snake
+// is its head.
+type object struct {
+	id        uint32
+	kind      types.ObjectType
+	dots      []types.Dot
+	direction types.Direction
+
+	// grow is the number of the ticks the snake keeps its tail.
+	grow int
+	// player controls the snake.
+	player *Player
+	// expires is the tick when the corpse disappears.
+	expires int
+}
+
+var objectTypeNames = map[types.ObjectType]string{
+	types.ObjectTypeSnake:      "snake",
+	types.ObjectTypeApple:      "apple",
+	types.ObjectTypeCorpse:     "corpse",
+	types.ObjectTypeMouse:      "mouse",
+	types.ObjectTypeWatermelon: "watermelon",
+	types.ObjectTypeWall:       "wall",
+}
+
+// wireDot is encoded as [x,y] like the dots sent by Snake-Server.
+type wireDot [2]uint8
+
+type wireObject struct {
+	Type      string          `json:"type"`
+	Id        uint32          `json:"id"`
+	Dot       *wireDot        `json:"dot,omitempty"`
+	Dots      []wireDot       `json:"dots,omitempty"`
+	Direction types.Direction `json:"direction,omitempty"`
+}
+
+func (o *object) wire() *wireObject {
+	w := &wireObject{
+		Type: objectTypeNames[o.kind],
+		Id:   o.id,
+	}
+
+	switch o.kind {
+	case types.ObjectTypeApple, types.ObjectTypeMouse:
+		dot := wireDot{o.dots[0].X, o.dots[0].Y}
+		w.Dot = &dot
+	default:
+		w.Dots = make([]wireDot, len(o.dots))
+		for i, dot := range o.dots {
+			w.Dots[i] = wireDot{dot.X, dot.Y}
+		}
+	}
+
+	if o.kind == types.ObjectTypeSnake || o.kind == types.ObjectTypeMouse {
+		w.Direction = o.direction
+	}
+
+	return w
+}
+
+func encodeMessage(messageType types.MessageType, eventType string,
+	payload interface{}) []byte {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		panic(err)
+	}
+	event, err := json.Marshal(struct {
+		Type    string          `json:"type"`
+		Payload json.RawMessage `json:"payload"`
+	}{
+		Type:    eventType,
+		Payload: data,
+	})
+	if err != nil {
+		panic(err)
+	}
+	message, err := json.Marshal(&types.Message{
+		Type:    messageType,
+		Payload: event,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return message
+}
+
+func gameEvent(eventType types.GameEventType, o *object) []byte {
+	return encodeMessage(types.MessageTypeGameEvent, string(eventType),
+		o.wire())
+}
+
+func playerEvent(eventType types.PlayerEventType,
+	payload interface{}) []byte {
+	return encodeMessage(types.MessageTypePlayer, string(eventType),
+		payload)
+}
@@ -0,0 +1,81 @@
+package simulator
+
+import (
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+// Player is a participant of the simulated game. It receives the same
+// messages as a websocket client of Snake-Server and controls a snake.
+type Player struct {
+	messages [][]byte
+	command  types.Direction
+
+	snake *object
+	// respawn is the tick when the player gets a new snake.
+	respawn int
+	left    bool
+
+	stats Stats
+}
+
+// Stats are the achievements of a player over the game.
+type Stats struct {
+	// Lives is the number of the snakes the player got.
+	Lives  int
+	Kills  int
+	Deaths int
+	// Ticks is the number of the ticks the player's snakes were alive.
+	Ticks int
+	// TotalLength is the sum of the lengths of the player's snakes
+	// over the ticks they were alive.
+	TotalLength int
+	MaxLength   int
+}
+
+// AverageLength returns the mean length of the player's snakes.
+func (s Stats) AverageLength() float64 {
+	if s.Ticks == 0 {
+		return 0
+	}
+	return float64(s.TotalLength) / float64(s.Ticks)
+}
+
+// AverageSurvival returns the mean number of the ticks the player's
+// snakes were alive.
+func (s Stats) AverageSurvival() float64 {
+	if s.Lives == 0 {
+		return 0
+	}
+	return float64(s.Ticks) / float64(s.Lives)
+}
+
+// Receive returns the messages sent to the player since the previous
+// call.
+func (p *Player) Receive() [][]byte {
+	messages := p.messages
+	p.messages = nil
+	return messages
+}
+
+// Send sets the direction of the player's snake. The direction is
+// applied on the next tick.
+func (p *Player) Send(direction types.Direction) {
+	p.command = direction
+}
+
+// SnakeId returns the id of the player's snake if the snake is alive.
+func (p *Player) SnakeId() (uint32, bool) {
+	if p.snake == nil {
+		return 0, false
+	}
+	return p.snake.id, true
+}
+
+// Stats returns the achievements of the player.
+func (p *Player) Stats() Stats {
+	return p.stats
+}
+
+func (p *Player) push(message []byte) {
+	p.messages = append(p.messages, message)
+}
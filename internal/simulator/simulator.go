@@ -0,0 +1,612 @@
+package simulator
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot/engine"
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+// Nutritional values are the numbers of the ticks a snake grows after
+// eating a dot of the food.
+const (
+	appleNutrition      = 1
+	corpseNutrition     = 2
+	watermelonNutrition = 5
+	mouseNutrition      = 15
+)
+
+const (
+	snakeStartLength = 3
+	// corpseLifetime is the number of the ticks a corpse lasts.
+	corpseLifetime = 100
+	// respawnDelay is the number of the ticks a player waits for a
+	// new snake after the death.
+	respawnDelay = 5
+	// mouseMoveEvery makes mice slower than snakes.
+	mouseMoveEvery = 2
+	// placeAttempts bounds the search of free dots for new objects.
+	placeAttempts = 64
+
+	wallMinLength = 3
+	wallMaxLength = 8
+)
+
+// Config describes the simulated game. The numbers of apples,
+// watermelons and mice are maintained: eaten objects are replaced
+// with new ones.
+type Config struct {
+	Width  uint8
+	Height uint8
+
+	Walls       int
+	Apples      int
+	Watermelons int
+	Mice        int
+
+	Seed int64
+}
+
+// Simulator is an in-process implementation of the rules of
+// Snake-Server which bots rely on. The area wraps around its edges.
+// Snakes move one dot per tick, grow after eating food and die when
+// they run into walls or into longer snakes. Shorter snakes get bitten
+// to death. Dead snakes turn into corpses.
+//
+// Simulator isn't safe for concurrent use.
+type Simulator struct {
+	config Config
+	area   engine.Area
+	random *rand.Rand
+
+	tick    int
+	nextId  uint32
+	objects map[uint32]*object
+	grid    []*object
+
+	snakes  []*object
+	mice    []*object
+	corpses []*object
+
+	apples      int
+	watermelons int
+
+	players []*Player
+}
+
+func New(config Config) *Simulator {
+	area := engine.NewArea(config.Width, config.Height)
+	s := &Simulator{
+		config: config,
+		area:   area,
+		random: rand.New(rand.NewSource(config.Seed)),
+
+		objects: make(map[uint32]*object),
+		grid:    make([]*object, int(area.Width)*int(area.Height)),
+	}
+
+	for i := 0; i < config.Walls; i++ {
+		s.placeWall()
+	}
+	s.refill()
+
+	return s
+}
+
+func (s *Simulator) Area() engine.Area {
+	return s.area
+}
+
+// Ticks returns the number of the ticks passed.
+func (s *Simulator) Ticks() int {
+	return s.tick
+}
+
+// Join adds a player to the game. The player gets the size of the area,
+// the objects and a new snake.
+func (s *Simulator) Join() *Player {
+	p := &Player{}
+	p.push(playerEvent(types.PlayerEventTypeSize, types.Size{
+		Width:  s.area.Width,
+		Height: s.area.Height,
+	}))
+	p.push(playerEvent(types.PlayerEventTypeObjects, s.wireObjects()))
+
+	s.players = append(s.players, p)
+	s.spawn(p)
+
+	return p
+}
+
+// Leave removes the player from the game. The player's snake dies.
+func (s *Simulator) Leave(p *Player) {
+	p.left = true
+	if p.snake != nil {
+		s.die(p.snake, nil)
+	}
+	for i, player := range s.players {
+		if player == p {
+			s.players = append(s.players[:i], s.players[i+1:]...)
+			break
+		}
+	}
+}
+
+// Tick moves the game one step forward.
+func (s *Simulator) Tick() {
+	s.tick++
+
+	for _, p := range s.players {
+		if p.snake != nil && p.command != "" &&
+			p.command != types.DirectionZero &&
+			p.command != opposite(p.snake.direction) {
+			p.snake.direction = p.command
+		}
+		p.command = ""
+	}
+
+	if s.tick%mouseMoveEvery == 0 {
+		for _, mouse := range s.mice {
+			s.moveMouse(mouse)
+		}
+	}
+
+	s.moveSnakes()
+	s.expireCorpses()
+	s.refill()
+
+	for _, p := range s.players {
+		if p.snake != nil {
+			p.stats.Ticks++
+			p.stats.TotalLength += len(p.snake.dots)
+			if len(p.snake.dots) > p.stats.MaxLength {
+				p.stats.MaxLength = len(p.snake.dots)
+			}
+		} else if s.tick >= p.respawn {
+			s.spawn(p)
+		}
+	}
+}
+
+func (s *Simulator) moveSnakes() {
+	heads := make(map[*object]types.Dot, len(s.snakes))
+	byHead := make(map[types.Dot][]*object, len(s.snakes))
+	snakes := append([]*object(nil), s.snakes...)
+	for _, snake := range snakes {
+		head := s.step(snake.dots[0], snake.direction)
+		heads[snake] = head
+		byHead[head] = append(byHead[head], snake)
+	}
+
+	// dead maps the snakes to die on this tick to their killers. Snakes
+	// crashed into walls or into themselves have no killers.
+	dead := make(map[*object]*object)
+
+	// In head-to-head collisions the longest snake survives.
+	for _, collided := range byHead {
+		if len(collided) < 2 {
+			continue
+		}
+		longest := collided[0]
+		for _, snake := range collided[1:] {
+			if len(snake.dots) > len(longest.dots) {
+				longest = snake
+			}
+		}
+		tie := countLength(collided, len(longest.dots)) > 1
+		for _, snake := range collided {
+			if tie {
+				dead[snake] = nil
+			} else if snake != longest {
+				dead[snake] = longest
+			}
+		}
+	}
+
+	// Tails move away unless the snakes grow. The food eaten on this
+	// tick makes the snakes grow starting from the next one. Snakes are
+	// compared by the lengths they have at the beginning of the tick.
+	lengths := make(map[*object]int, len(snakes))
+	for _, snake := range snakes {
+		lengths[snake] = len(snake.dots)
+		if snake.grow > 0 {
+			snake.grow--
+			continue
+		}
+		tail := snake.dots[len(snake.dots)-1]
+		if s.at(tail) == snake {
+			s.set(tail, nil)
+		}
+		snake.dots = snake.dots[:len(snake.dots)-1]
+	}
+
+	for _, snake := range snakes {
+		if _, ok := dead[snake]; ok {
+			continue
+		}
+		head := heads[snake]
+
+		if target := s.at(head); target != nil {
+			switch target.kind {
+			case types.ObjectTypeWall:
+				dead[snake] = nil
+				continue
+			case types.ObjectTypeSnake:
+				if target == snake {
+					dead[snake] = nil
+					continue
+				}
+				if lengths[snake] <= lengths[target] {
+					dead[snake] = target
+					continue
+				}
+				// The longer snake bites the shorter one to death
+				// and eats a dot of its corpse.
+				dead[target] = snake
+				if corpse := s.die(target, snake); corpse != nil && s.at(head) == corpse {
+					s.eat(snake, corpse, head)
+				}
+			default:
+				s.eat(snake, target, head)
+			}
+		}
+
+		snake.dots = append([]types.Dot{head}, snake.dots...)
+		s.set(head, snake)
+
+		s.broadcast(gameEvent(types.GameEventTypeUpdate, snake))
+	}
+
+	for _, snake := range snakes {
+		if killer, ok := dead[snake]; ok && s.objects[snake.id] == snake {
+			s.die(snake, killer)
+		}
+	}
+}
+
+func countLength(snakes []*object, length int) int {
+	n := 0
+	for _, snake := range snakes {
+		if len(snake.dots) == length {
+			n++
+		}
+	}
+	return n
+}
+
+// eat makes the snake eat the dot of the food.
+func (s *Simulator) eat(snake, food *object, dot types.Dot) {
+	switch food.kind {
+	case types.ObjectTypeApple:
+		snake.grow += appleNutrition
+		s.apples--
+		s.remove(food)
+	case types.ObjectTypeMouse:
+		snake.grow += mouseNutrition
+		s.mice = without(s.mice, food)
+		s.remove(food)
+	case types.ObjectTypeCorpse, types.ObjectTypeWatermelon:
+		if food.kind == types.ObjectTypeCorpse {
+			snake.grow += corpseNutrition
+		} else {
+			snake.grow += watermelonNutrition
+		}
+		s.set(dot, nil)
+		food.dots = withoutDot(food.dots, dot)
+		if len(food.dots) > 0 {
+			s.broadcast(gameEvent(types.GameEventTypeUpdate, food))
+			return
+		}
+		if food.kind == types.ObjectTypeCorpse {
+			s.corpses = without(s.corpses, food)
+		} else {
+			s.watermelons--
+		}
+		s.remove(food)
+	}
+}
+
+// die turns the snake into a corpse. The dots of the snake which are
+// taken by other objects are left out of the corpse. The killer gets
+// the credit for the death if it is set.
+func (s *Simulator) die(snake, killer *object) *object {
+	s.snakes = without(s.snakes, snake)
+	s.remove(snake)
+
+	if killer != nil && killer.player != nil {
+		killer.player.stats.Kills++
+	}
+
+	if p := snake.player; p != nil {
+		if !p.left {
+			p.stats.Deaths++
+		}
+		p.snake = nil
+		p.respawn = s.tick + respawnDelay
+		p.push(playerEvent(types.PlayerEventTypeCountdown, respawnDelay))
+	}
+
+	dots := make([]types.Dot, 0, len(snake.dots))
+	for _, dot := range snake.dots {
+		if s.at(dot) == nil && !containsDot(dots, dot) {
+			dots = append(dots, dot)
+		}
+	}
+	if len(dots) == 0 {
+		return nil
+	}
+
+	corpse := s.add(types.ObjectTypeCorpse, dots, "")
+	corpse.expires = s.tick + corpseLifetime
+	s.corpses = append(s.corpses, corpse)
+	return corpse
+}
+
+func (s *Simulator) expireCorpses() {
+	for _, corpse := range append([]*object(nil), s.corpses...) {
+		if s.tick >= corpse.expires {
+			s.corpses = without(s.corpses, corpse)
+			s.remove(corpse)
+		}
+	}
+}
+
+func (s *Simulator) moveMouse(mouse *object) {
+	directions := [...]types.Direction{
+		types.DirectionNorth,
+		types.DirectionSouth,
+		types.DirectionEast,
+		types.DirectionWest,
+	}
+	direction := directions[s.random.Intn(len(directions))]
+	dot := s.step(mouse.dots[0], direction)
+	if s.at(dot) != nil {
+		return
+	}
+
+	s.set(mouse.dots[0], nil)
+	mouse.dots[0] = dot
+	mouse.direction = direction
+	s.set(dot, mouse)
+
+	s.broadcast(gameEvent(types.GameEventTypeUpdate, mouse))
+}
+
+func (s *Simulator) refill() {
+	for s.apples < s.config.Apples {
+		dot, ok := s.freeDot()
+		if !ok {
+			break
+		}
+		s.add(types.ObjectTypeApple, []types.Dot{dot}, "")
+		s.apples++
+	}
+
+	for s.watermelons < s.config.Watermelons {
+		dots, ok := s.freeSquare()
+		if !ok {
+			break
+		}
+		s.add(types.ObjectTypeWatermelon, dots, "")
+		s.watermelons++
+	}
+
+	for len(s.mice) < s.config.Mice {
+		dot, ok := s.freeDot()
+		if !ok {
+			break
+		}
+		mouse := s.add(types.ObjectTypeMouse, []types.Dot{dot},
+			types.DirectionNorth)
+		s.mice = append(s.mice, mouse)
+	}
+}
+
+// spawn creates a snake for the player. If there is no room for the
+// snake, the player waits for the next tick.
+func (s *Simulator) spawn(p *Player) {
+	for i := 0; i < placeAttempts; i++ {
+		direction := randomDirection(s.random)
+		dots := []types.Dot{s.randomDot()}
+		for len(dots) < snakeStartLength {
+			dots = append(dots, s.step(dots[len(dots)-1], opposite(direction)))
+		}
+		if !s.allFree(dots) {
+			continue
+		}
+
+		snake := s.add(types.ObjectTypeSnake, dots, direction)
+		snake.player = p
+		s.snakes = append(s.snakes, snake)
+
+		p.snake = snake
+		p.stats.Lives++
+		p.push(playerEvent(types.PlayerEventTypeSnake, snake.id))
+		return
+	}
+
+	p.respawn = s.tick + 1
+}
+
+func (s *Simulator) placeWall() {
+	direction := randomDirection(s.random)
+	length := wallMinLength + s.random.Intn(wallMaxLength-wallMinLength+1)
+
+	dots := []types.Dot{s.randomDot()}
+	for len(dots) < length {
+		dots = append(dots, s.step(dots[len(dots)-1], direction))
+	}
+	if s.allFree(dots) {
+		s.add(types.ObjectTypeWall, dots, "")
+	}
+}
+
+func (s *Simulator) add(kind types.ObjectType, dots []types.Dot,
+	direction types.Direction) *object {
+	s.nextId++
+	o := &object{
+		id:        s.nextId,
+		kind:      kind,
+		dots:      dots,
+		direction: direction,
+	}
+	s.objects[o.id] = o
+	for _, dot := range dots {
+		s.set(dot, o)
+	}
+
+	s.broadcast(gameEvent(types.GameEventTypeCreate, o))
+
+	return o
+}
+
+func (s *Simulator) remove(o *object) {
+	delete(s.objects, o.id)
+	for _, dot := range o.dots {
+		if s.at(dot) == o {
+			s.set(dot, nil)
+		}
+	}
+
+	s.broadcast(gameEvent(types.GameEventTypeDelete, o))
+}
+
+func (s *Simulator) broadcast(message []byte) {
+	for _, p := range s.players {
+		p.push(message)
+	}
+}
+
+func (s *Simulator) wireObjects() []*wireObject {
+	ids := make([]uint32, 0, len(s.objects))
+	for id := range s.objects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	objects := make([]*wireObject, len(ids))
+	for i, id := range ids {
+		objects[i] = s.objects[id].wire()
+	}
+	return objects
+}
+
+func (s *Simulator) at(dot types.Dot) *object {
+	return s.grid[int(dot.Y)*int(s.area.Width)+int(dot.X)]
+}
+
+func (s *Simulator) set(dot types.Dot, o *object) {
+	s.grid[int(dot.Y)*int(s.area.Width)+int(dot.X)] = o
+}
+
+func (s *Simulator) allFree(dots []types.Dot) bool {
+	for i, dot := range dots {
+		if s.at(dot) != nil || containsDot(dots[:i], dot) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Simulator) randomDot() types.Dot {
+	return types.Dot{
+		X: uint8(s.random.Intn(int(s.area.Width))),
+		Y: uint8(s.random.Intn(int(s.area.Height))),
+	}
+}
+
+func (s *Simulator) freeDot() (types.Dot, bool) {
+	for i := 0; i < placeAttempts; i++ {
+		if dot := s.randomDot(); s.at(dot) == nil {
+			return dot, true
+		}
+	}
+	return types.Dot{}, false
+}
+
+func (s *Simulator) freeSquare() ([]types.Dot, bool) {
+	for i := 0; i < placeAttempts; i++ {
+		corner := s.randomDot()
+		east := s.step(corner, types.DirectionEast)
+		dots := []types.Dot{
+			corner,
+			east,
+			s.step(corner, types.DirectionSouth),
+			s.step(east, types.DirectionSouth),
+		}
+		if s.allFree(dots) {
+			return dots, true
+		}
+	}
+	return nil, false
+}
+
+// step returns the dot next to the given one in the direction.
+func (s *Simulator) step(dot types.Dot, direction types.Direction) types.Dot {
+	dots := s.area.Navigate(dot)
+	switch direction {
+	case types.DirectionNorth:
+		return dots[0]
+	case types.DirectionSouth:
+		return dots[1]
+	case types.DirectionEast:
+		return dots[2]
+	case types.DirectionWest:
+		return dots[3]
+	}
+	return dot
+}
+
+func randomDirection(r *rand.Rand) types.Direction {
+	directions := [...]types.Direction{
+		types.DirectionNorth,
+		types.DirectionSouth,
+		types.DirectionEast,
+		types.DirectionWest,
+	}
+	return directions[r.Intn(len(directions))]
+}
+
+func opposite(direction types.Direction) types.Direction {
+	switch direction {
+	case types.DirectionNorth:
+		return types.DirectionSouth
+	case types.DirectionSouth:
+		return types.DirectionNorth
+	case types.DirectionEast:
+		return types.DirectionWest
+	case types.DirectionWest:
+		return types.DirectionEast
+	}
+	return types.DirectionZero
+}
+
+func without(objects []*object, o *object) []*object {
+	for i, item := range objects {
+		if item == o {
+			return append(objects[:i], objects[i+1:]...)
+		}
+	}
+	return objects
+}
+
+func withoutDot(dots []types.Dot, dot types.Dot) []types.Dot {
+	for i, item := range dots {
+		if item == dot {
+			return append(dots[:i], dots[i+1:]...)
+		}
+	}
+	return dots
+}
+
+func containsDot(dots []types.Dot, dot types.Dot) bool {
+	for _, item := range dots {
+		if item == dot {
+			return true
+		}
+	}
+	return false
+}
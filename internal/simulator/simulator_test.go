@@ -0,0 +1,236 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/parser"
+	"github.com/ivan1993spb/snake-bot/internal/types"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+func joinAt(s *Simulator, direction types.Direction,
+	dots ...types.Dot) *Player {
+	p := &Player{}
+	s.players = append(s.players, p)
+
+	snake := s.add(types.ObjectTypeSnake, dots, direction)
+	snake.player = p
+	s.snakes = append(s.snakes, snake)
+	p.snake = snake
+
+	return p
+}
+
+func Test_Simulator_Join(t *testing.T) {
+	s := New(Config{
+		Width:       30,
+		Height:      20,
+		Walls:       3,
+		Apples:      4,
+		Watermelons: 1,
+		Mice:        2,
+		Seed:        1,
+	})
+	p := s.Join()
+
+	profile, _ := bot.NewProfiles().Get(bot.DefaultProfileName)
+	game := bot.NewGame()
+	b, err := bot.NewBotWithDiscoverer(game, bot.DiscovererDijkstras, profile, 1)
+	require.Nil(t, err)
+	parser := &parser.Parser{
+		Countdown: b,
+		Me:        b,
+		Size:      game,
+		Game:      game,
+		Printer:   utils.PrinterDevNull{},
+	}
+	for _, message := range p.Receive() {
+		require.Nil(t, parser.Parse(message))
+	}
+
+	require.Equal(t, uint8(30), game.GetArea().Width)
+	require.Equal(t, uint8(20), game.GetArea().Height)
+
+	id, ok := p.SnakeId()
+	require.True(t, ok)
+	snake, ok := game.GetObject(id)
+	require.True(t, ok)
+	require.Equal(t, types.ObjectTypeSnake, snake.Type)
+	require.Len(t, snake.Dots, snakeStartLength)
+
+	for id, o := range s.objects {
+		object, ok := game.GetObject(id)
+		require.True(t, ok)
+		require.Equal(t, o.kind, object.Type)
+		require.Equal(t, o.dots, object.GetDots())
+	}
+
+	_, ok = b.Tick(context.Background())
+	require.True(t, ok)
+}
+
+func Test_Simulator_Tick_Move(t *testing.T) {
+	s := New(Config{Width: 10, Height: 10})
+	p := joinAt(s, types.DirectionEast,
+		types.Dot{X: 9, Y: 5}, types.Dot{X: 8, Y: 5}, types.Dot{X: 7, Y: 5})
+
+	s.Tick()
+	require.Equal(t, []types.Dot{{X: 0, Y: 5}, {X: 9, Y: 5}, {X: 8, Y: 5}},
+		p.snake.dots)
+	require.Nil(t, s.at(types.Dot{X: 7, Y: 5}))
+
+	// Snakes cannot turn back
+	p.Send(types.DirectionWest)
+	s.Tick()
+	require.Equal(t, types.Dot{X: 1, Y: 5}, p.snake.dots[0])
+
+	p.Send(types.DirectionNorth)
+	s.Tick()
+	require.Equal(t, types.Dot{X: 1, Y: 4}, p.snake.dots[0])
+	require.Equal(t, types.DirectionNorth, p.snake.direction)
+}
+
+func Test_Simulator_Tick_Eat(t *testing.T) {
+	s := New(Config{Width: 10, Height: 10})
+	p := joinAt(s, types.DirectionEast,
+		types.Dot{X: 2, Y: 5}, types.Dot{X: 1, Y: 5}, types.Dot{X: 0, Y: 5})
+	s.add(types.ObjectTypeApple, []types.Dot{{X: 3, Y: 5}}, "")
+	s.apples++
+
+	s.Tick()
+	require.Equal(t, 0, s.apples)
+	require.Equal(t, appleNutrition, p.snake.grow)
+	require.Len(t, p.snake.dots, 3)
+
+	s.Tick()
+	require.Equal(t, 0, p.snake.grow)
+	require.Equal(t, []types.Dot{{X: 4, Y: 5}, {X: 3, Y: 5}, {X: 2, Y: 5},
+		{X: 1, Y: 5}}, p.snake.dots)
+}
+
+func Test_Simulator_Tick_Wall(t *testing.T) {
+	s := New(Config{Width: 10, Height: 10})
+	p := joinAt(s, types.DirectionEast,
+		types.Dot{X: 2, Y: 5}, types.Dot{X: 1, Y: 5}, types.Dot{X: 0, Y: 5})
+	s.add(types.ObjectTypeWall, []types.Dot{{X: 3, Y: 4}, {X: 3, Y: 5}}, "")
+	p.Receive()
+
+	s.Tick()
+	_, ok := p.SnakeId()
+	require.False(t, ok)
+	require.Len(t, s.corpses, 1)
+	require.Equal(t, []types.Dot{{X: 2, Y: 5}, {X: 1, Y: 5}},
+		s.corpses[0].dots)
+	require.Contains(t, p.Receive(),
+		playerEvent(types.PlayerEventTypeCountdown, respawnDelay))
+
+	for i := 0; i < respawnDelay; i++ {
+		s.Tick()
+	}
+	_, ok = p.SnakeId()
+	require.True(t, ok)
+	require.Equal(t, Stats{
+		Lives:  1,
+		Deaths: 1,
+	}, p.Stats())
+}
+
+func Test_Simulator_Tick_HeadToHead(t *testing.T) {
+	s := New(Config{Width: 10, Height: 10})
+	p1 := joinAt(s, types.DirectionEast,
+		types.Dot{X: 2, Y: 5}, types.Dot{X: 1, Y: 5}, types.Dot{X: 0, Y: 5})
+	p2 := joinAt(s, types.DirectionWest,
+		types.Dot{X: 4, Y: 5}, types.Dot{X: 5, Y: 5}, types.Dot{X: 6, Y: 5})
+
+	s.Tick()
+	_, ok := p1.SnakeId()
+	require.False(t, ok)
+	_, ok = p2.SnakeId()
+	require.False(t, ok)
+	require.Zero(t, p1.Stats().Kills)
+	require.Zero(t, p2.Stats().Kills)
+}
+
+func Test_Simulator_Tick_Bite(t *testing.T) {
+	s := New(Config{Width: 10, Height: 10})
+	hunter := joinAt(s, types.DirectionEast,
+		types.Dot{X: 3, Y: 5}, types.Dot{X: 2, Y: 5}, types.Dot{X: 1, Y: 5},
+		types.Dot{X: 0, Y: 5})
+	prey := joinAt(s, types.DirectionNorth,
+		types.Dot{X: 4, Y: 4}, types.Dot{X: 4, Y: 5}, types.Dot{X: 4, Y: 6})
+
+	s.Tick()
+	_, ok := prey.SnakeId()
+	require.False(t, ok)
+	_, ok = hunter.SnakeId()
+	require.True(t, ok)
+
+	require.Equal(t, types.Dot{X: 4, Y: 5}, hunter.snake.dots[0])
+	require.Equal(t, corpseNutrition, hunter.snake.grow)
+	require.Len(t, s.corpses, 1)
+	require.Equal(t, []types.Dot{{X: 4, Y: 4}}, s.corpses[0].dots)
+
+	require.Equal(t, Stats{
+		Kills:       1,
+		Ticks:       1,
+		TotalLength: 4,
+		MaxLength:   4,
+	}, hunter.Stats())
+	require.Equal(t, 1, prey.Stats().Deaths)
+}
+
+func Benchmark_Simulator_Bots(b *testing.B) {
+	const bots = 4
+
+	ctx := context.Background()
+	s := New(Config{
+		Width:       40,
+		Height:      40,
+		Walls:       10,
+		Apples:      20,
+		Watermelons: 2,
+		Mice:        4,
+		Seed:        1,
+	})
+
+	profile, _ := bot.NewProfiles().Get(bot.DefaultProfileName)
+	players := make([]*Player, bots)
+	parsers := make([]*parser.Parser, bots)
+	snakes := make([]*bot.Bot, bots)
+	for i := range players {
+		game := bot.NewGame()
+		snake, err := bot.NewBotWithDiscoverer(game, bot.DiscovererDijkstras,
+			profile, int64(i))
+		if err != nil {
+			b.Fatal(err)
+		}
+		snakes[i] = snake
+		parsers[i] = &parser.Parser{
+			Countdown: snakes[i],
+			Me:        snakes[i],
+			Size:      game,
+			Game:      game,
+			Printer:   utils.PrinterDevNull{},
+		}
+		players[i] = s.Join()
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i, p := range players {
+			for _, message := range p.Receive() {
+				if err := parsers[i].Parse(message); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if direction, ok := snakes[i].Tick(ctx); ok {
+				p.Send(direction)
+			}
+		}
+		s.Tick()
+	}
+}
@@ -0,0 +1,287 @@
+// Package snaketest provides a local Snake-Server for integration tests.
+// The games are played by the in-process simulator and are served over
+// websocket the same way Snake-Server does.
+package snaketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ivan1993spb/snake-bot/internal/simulator"
+	"github.com/ivan1993spb/snake-bot/internal/types"
+)
+
+const defaultTick = time.Millisecond * 50
+
+// Config describes the server. Every game id gets a game simulated with
+// the game config. Connections to other games are refused with 404.
+type Config struct {
+	Games []int
+	Game  simulator.Config
+	// Limit is the players limit reported by the games API.
+	Limit int
+	// Tick is the interval between the ticks of the games.
+	Tick time.Duration
+}
+
+// Server is a fake Snake-Server. Tests should call Close when done.
+type Server struct {
+	server *httptest.Server
+	games  map[int]*game
+	ids    []int
+	limit  int
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+type game struct {
+	id int
+
+	mux      sync.Mutex
+	sim      *simulator.Simulator
+	players  map[*simulator.Player]chan struct{}
+	conns    map[*websocket.Conn]struct{}
+	commands int
+}
+
+const gameWebsocketPathFormat = "/ws/games/%d"
+
+var upgrader = websocket.Upgrader{}
+
+// NewServer starts a server.
+func NewServer(config Config) *Server {
+	s := &Server{
+		games: make(map[int]*game, len(config.Games)),
+		ids:   append([]int(nil), config.Games...),
+		limit: config.Limit,
+		stop:  make(chan struct{}),
+	}
+	sort.Ints(s.ids)
+
+	tick := config.Tick
+	if tick <= 0 {
+		tick = defaultTick
+	}
+
+	mux := http.NewServeMux()
+	for _, id := range config.Games {
+		g := &game{
+			id:      id,
+			sim:     simulator.New(config.Game),
+			players: make(map[*simulator.Player]chan struct{}),
+			conns:   make(map[*websocket.Conn]struct{}),
+		}
+		s.games[id] = g
+		mux.Handle(fmt.Sprintf(gameWebsocketPathFormat, id), s.handle(g))
+
+		s.wg.Add(1)
+		go s.run(g, tick)
+	}
+
+	mux.HandleFunc(gamesPath, s.handleGames)
+
+	s.server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Address returns host:port of the server.
+func (s *Server) Address() string {
+	return s.server.Listener.Addr().String()
+}
+
+// Close stops the games and closes the connections.
+func (s *Server) Close() {
+	close(s.stop)
+	s.wg.Wait()
+
+	for _, g := range s.games {
+		g.mux.Lock()
+		for conn := range g.conns {
+			conn.Close()
+		}
+		g.mux.Unlock()
+	}
+
+	s.server.Close()
+}
+
+// Players returns the number of the players in the game.
+func (s *Server) Players(gameId int) int {
+	g, ok := s.games[gameId]
+	if !ok {
+		return 0
+	}
+
+	g.mux.Lock()
+	defer g.mux.Unlock()
+
+	return len(g.players)
+}
+
+// Commands returns the number of the snake commands received in
+// the game.
+func (s *Server) Commands(gameId int) int {
+	g, ok := s.games[gameId]
+	if !ok {
+		return 0
+	}
+
+	g.mux.Lock()
+	defer g.mux.Unlock()
+
+	return g.commands
+}
+
+const gamesPath = "/api/games"
+
+type gameInfo struct {
+	Id     int   `json:"id"`
+	Limit  int   `json:"limit"`
+	Count  int   `json:"count"`
+	Width  uint8 `json:"width"`
+	Height uint8 `json:"height"`
+	Rate   int   `json:"rate"`
+}
+
+// handleGames lists the games like the games API of Snake-Server.
+func (s *Server) handleGames(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	games := make([]*gameInfo, 0, len(s.ids))
+	for _, id := range s.ids {
+		g := s.games[id]
+		area := g.sim.Area()
+		games = append(games, &gameInfo{
+			Id:     id,
+			Limit:  s.limit,
+			Count:  s.Players(id),
+			Width:  area.Width,
+			Height: area.Height,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"games": games,
+		"limit": len(games),
+		"count": len(games),
+	})
+}
+
+func (s *Server) run(g *game, tick time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			g.mux.Lock()
+			g.sim.Tick()
+			for _, notify := range g.players {
+				wake(notify)
+			}
+			g.mux.Unlock()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func wake(notify chan struct{}) {
+	select {
+	case notify <- struct{}{}:
+	default:
+	}
+}
+
+type command struct {
+	Type    string          `json:"type"`
+	Payload types.Direction `json:"payload"`
+}
+
+const commandTypeSnake = "snake"
+
+func (s *Server) handle(g *game) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		notify := make(chan struct{}, 1)
+		g.mux.Lock()
+		p := g.sim.Join()
+		g.players[p] = notify
+		g.conns[conn] = struct{}{}
+		g.mux.Unlock()
+
+		defer func() {
+			g.mux.Lock()
+			g.sim.Leave(p)
+			delete(g.players, p)
+			delete(g.conns, conn)
+			g.mux.Unlock()
+
+			conn.Close()
+		}()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			for {
+				_, data, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+
+				var c command
+				if err := json.Unmarshal(data, &c); err != nil ||
+					c.Type != commandTypeSnake {
+					continue
+				}
+
+				g.mux.Lock()
+				p.Send(c.Payload)
+				g.commands++
+				g.mux.Unlock()
+			}
+		}()
+
+		wake(notify)
+
+		for {
+			select {
+			case <-notify:
+			case <-done:
+				return
+			case <-s.stop:
+				return
+			}
+
+			g.mux.Lock()
+			messages := p.Receive()
+			g.mux.Unlock()
+
+			for _, message := range messages {
+				if err := conn.WriteMessage(websocket.TextMessage,
+					message); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
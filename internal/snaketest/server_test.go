@@ -0,0 +1,94 @@
+package snaketest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ivan1993spb/snake-bot/internal/bot"
+	"github.com/ivan1993spb/snake-bot/internal/parser"
+	"github.com/ivan1993spb/snake-bot/internal/simulator"
+	"github.com/ivan1993spb/snake-bot/internal/types"
+	"github.com/ivan1993spb/snake-bot/internal/utils"
+)
+
+type me struct {
+	id uint32
+}
+
+func (m *me) Me(id uint32) {
+	m.id = id
+}
+
+func (m *me) Countdown(int) {}
+
+func Test_Server(t *testing.T) {
+	s := NewServer(Config{
+		Games: []int{1},
+		Game: simulator.Config{
+			Width:  20,
+			Height: 20,
+			Apples: 5,
+		},
+		Tick: time.Millisecond * 10,
+	})
+	defer s.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws://"+s.Address()+"/ws/games/1", nil)
+	require.Nil(t, err)
+	defer conn.Close()
+
+	game := bot.NewGame()
+	m := &me{}
+	p := &parser.Parser{
+		Countdown: m,
+		Me:        m,
+		Size:      game,
+		Game:      game,
+		Printer:   utils.PrinterDevNull{},
+	}
+
+	for m.id == 0 {
+		_, message, err := conn.ReadMessage()
+		require.Nil(t, err)
+		require.Nil(t, p.Parse(message))
+	}
+	require.Equal(t, uint8(20), game.GetArea().Width)
+
+	snake, ok := game.GetObject(m.id)
+	require.True(t, ok)
+	require.Equal(t, types.ObjectTypeSnake, snake.Type)
+	require.Equal(t, 1, s.Players(1))
+
+	err = conn.WriteJSON(types.DirectionNorth.ToMessageSnakeCommand())
+	require.Nil(t, err)
+	require.Eventually(t, func() bool {
+		return s.Commands(1) == 1
+	}, time.Second, time.Millisecond*10)
+
+	require.Nil(t, conn.Close())
+	require.Eventually(t, func() bool {
+		return s.Players(1) == 0
+	}, time.Second, time.Millisecond*10)
+}
+
+func Test_Server_UnknownGame(t *testing.T) {
+	s := NewServer(Config{
+		Games: []int{1},
+		Game: simulator.Config{
+			Width:  20,
+			Height: 20,
+		},
+	})
+	defer s.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial(
+		"ws://"+s.Address()+"/ws/games/2", nil)
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Zero(t, s.Players(2))
+}
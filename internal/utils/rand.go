@@ -13,11 +13,36 @@ import (
 // - Discuss: https://github.com/golang/go/issues/3611
 // - Solution: https://github.com/mesosphere/mesos-dns/pull/317
 func NewRand(clock Clock) *rand.Rand {
+	return NewRandSeed(clock.Now().UnixNano())
+}
+
+// NewRandSeed returns a new Rand that uses a locked rand.Source seeded
+// with the given value.
+func NewRandSeed(seed int64) *rand.Rand {
 	return rand.New(&lockedSource{
-		src: rand.NewSource(clock.Now().UnixNano()),
+		src: rand.NewSource(seed),
 	})
 }
 
+// DeriveSeed mixes the values into the seed. Equal arguments always give
+// equal seeds, whereas close arguments give seeds far from each other.
+//
+// Link: https://prng.di.unimi.it/splitmix64.c
+func DeriveSeed(seed int64, values ...int64) int64 {
+	x := uint64(seed)
+	for _, v := range values {
+		x = splitmix64(x ^ uint64(v))
+	}
+	return int64(splitmix64(x))
+}
+
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
 // lockedSource wraps a rand.Source with a sync.Mutex for synchronization.
 type lockedSource struct {
 	src rand.Source